@@ -0,0 +1,59 @@
+package lexkey
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// Base64Key is a LexKey that serializes to JSON and text as unpadded base64url instead of
+// hex, cutting the encoded size from 2 characters per byte to about 1.33. Convert with
+// Base64Key(key) and LexKey(b64). Empty and null inputs decode to an empty (non-nil) key.
+type Base64Key LexKey
+
+// MarshalJSON encodes the key as a base64url JSON string.
+func (k Base64Key) MarshalJSON() ([]byte, error) {
+	text, err := k.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(text)+2)
+	out[0] = '"'
+	copy(out[1:], text)
+	out[len(out)-1] = '"'
+	return out, nil
+}
+
+// UnmarshalJSON decodes a base64url JSON string into the key.
+// Handles JSON null by setting to an empty slice.
+func (k *Base64Key) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*k = []byte{}
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		return k.UnmarshalText(data[1 : len(data)-1])
+	}
+	return fmt.Errorf("cannot unmarshal JSON into Base64Key: invalid format")
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the unpadded base64url encoding.
+func (k Base64Key) MarshalText() ([]byte, error) {
+	dst := make([]byte, base64.RawURLEncoding.EncodedLen(len(k)))
+	base64.RawURLEncoding.Encode(dst, k)
+	return dst, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding unpadded base64url input.
+func (k *Base64Key) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*k = []byte{}
+		return nil
+	}
+	dst := make([]byte, base64.RawURLEncoding.DecodedLen(len(text)))
+	n, err := base64.RawURLEncoding.Decode(dst, text)
+	if err != nil {
+		return fmt.Errorf("cannot decode Base64Key: %w: %w", ErrInvalidBase64, err)
+	}
+	*k = dst[:n]
+	return nil
+}
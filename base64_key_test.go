@@ -0,0 +1,114 @@
+package lexkey
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldMarshalBase64KeyToJSON(t *testing.T) {
+	// Arrange
+	key := Base64Key(Encode("a", "b"))
+
+	// Act
+	data, err := json.Marshal(key)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, `"YQBi"`, string(data))
+}
+
+func TestShouldRoundTripBase64KeyThroughJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		key  LexKey
+	}{
+		{"empty", LexKey{}},
+		{"single byte", LexKey{0xff}},
+		{"url-unsafe bytes", LexKey{0xfb, 0xff, 0xbf}},
+		{"mixed parts", Encode("tenant", uuid.New(), 42, true)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			data, err := json.Marshal(Base64Key(tt.key))
+			require.NoError(t, err)
+			var decoded Base64Key
+			err = json.Unmarshal(data, &decoded)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.key, LexKey(decoded))
+			assert.NotContains(t, string(data), "+")
+			assert.NotContains(t, string(data), "/")
+			assert.NotContains(t, string(data), "=")
+		})
+	}
+}
+
+func TestShouldUnmarshalNullBase64KeyToEmptySlice(t *testing.T) {
+	// Arrange
+	var key Base64Key
+
+	// Act
+	err := json.Unmarshal([]byte("null"), &key)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, key)
+	assert.Empty(t, key)
+}
+
+func TestShouldEncodeBase64KeySmallerThanHex(t *testing.T) {
+	// Arrange
+	key := Encode("tenant", uuid.New(), 42)
+
+	// Act
+	hexJSON, err := json.Marshal(key)
+	require.NoError(t, err)
+	base64JSON, err := json.Marshal(Base64Key(key))
+	require.NoError(t, err)
+
+	// Assert
+	assert.Less(t, len(base64JSON), len(hexJSON))
+	assert.Equal(t, (len(key)*4+2)/3+2, len(base64JSON))
+}
+
+func TestShouldRejectInvalidBase64Key(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"invalid character", `"a*b"`},
+		{"padded standard encoding", `"YQ=="`},
+		{"not a string", `123`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var key Base64Key
+
+			// Act
+			err := json.Unmarshal([]byte(tt.input), &key)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestShouldMatchErrInvalidBase64(t *testing.T) {
+	// Arrange
+	var key Base64Key
+
+	// Act
+	err := key.UnmarshalText([]byte("a*b"))
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidBase64)
+}
@@ -0,0 +1,57 @@
+package lexkey
+
+import "fmt"
+
+// EncodeBatch encodes each row of parts like NewLexKey, in two passes over the batch so all
+// keys share one allocation sized up front. Each returned key is capped at its own length,
+// so keys are independent: appending to one reallocates instead of overwriting its neighbor.
+// Returns an error naming the first row that fails to encode.
+func EncodeBatch(rows [][]any) ([]LexKey, error) {
+	return defaultEncoder.EncodeBatch(rows)
+}
+
+// EncodeBatch encodes each row of parts like the encoder's NewLexKey; see the package-level EncodeBatch.
+func (enc *Encoder) EncodeBatch(rows [][]any) ([]LexKey, error) {
+	if err := enc.validate(); err != nil {
+		return nil, err
+	}
+	parts := 0
+	for _, row := range rows {
+		parts += len(row)
+	}
+	canon := make([]any, 0, parts)
+	sizes := make([]int, len(rows))
+	total := 0
+	for i, row := range rows {
+		if len(row) == 0 {
+			return nil, fmt.Errorf("cannot encode row %d: %w", i, ErrEmptyKey)
+		}
+		if err := enc.checkStrict(row); err != nil {
+			return nil, fmt.Errorf("cannot encode row %d: %w", i, err)
+		}
+		start := len(canon)
+		for _, p := range row {
+			canon = append(canon, canonicalizePart(p))
+		}
+		sizes[i] = enc.estimateSize(canon[start:])
+		if err := enc.checkLen(sizes[i]); err != nil {
+			return nil, fmt.Errorf("cannot encode row %d: %w", i, err)
+		}
+		total += sizes[i]
+	}
+
+	buf := make([]byte, total)
+	keys := make([]LexKey, len(rows))
+	pos, start := 0, 0
+	for i, row := range rows {
+		end := start + len(row)
+		n, err := enc.encodeParts(buf[pos:pos+sizes[i]], canon[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode row %d: %w", i, err)
+		}
+		keys[i] = LexKey(buf[pos : pos+n : pos+n])
+		pos += sizes[i]
+		start = end
+	}
+	return keys, nil
+}
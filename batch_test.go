@@ -0,0 +1,73 @@
+package lexkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldMatchNewLexKeyForEachRowInBatch(t *testing.T) {
+	// Arrange
+	rows := [][]any{
+		{"tenant", 1, true},
+		{"tenant", 2, false},
+		{"other", nil, 3.5, struct{}{}},
+	}
+
+	// Act
+	keys, err := EncodeBatch(rows)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, keys, len(rows))
+	for i, row := range rows {
+		assert.Equal(t, Encode(row...), keys[i])
+	}
+}
+
+func TestShouldReturnIndependentKeysFromBatch(t *testing.T) {
+	// Arrange
+	keys, err := EncodeBatch([][]any{{"a"}, {"b"}})
+	require.NoError(t, err)
+
+	// Act
+	extended := append(keys[0], 'x')
+	keys[1][0] = 'z'
+
+	// Assert
+	assert.Equal(t, LexKey("ax"), extended)
+	assert.Equal(t, Encode("a"), keys[0])
+	assert.Equal(t, LexKey("z"), keys[1])
+}
+
+func TestShouldReturnEmptyResultForEmptyBatch(t *testing.T) {
+	// Act
+	keys, err := EncodeBatch(nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestShouldNameFailingRowInBatchError(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     [][]any
+		expected error
+	}{
+		{"empty row", [][]any{{"a"}, {}}, ErrEmptyKey},
+		{"unsupported part", [][]any{{"a"}, {map[string]int{}}}, ErrUnsupportedType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := EncodeBatch(tt.rows)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Contains(t, err.Error(), "row 1")
+		})
+	}
+}
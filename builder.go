@@ -0,0 +1,64 @@
+package lexkey
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Builder assembles a key part by part into a reusable buffer, for callers that build many
+// keys or add parts conditionally. Appending the same parts as NewLexKey produces the same
+// key. The zero value is an empty Builder ready to use; a Builder is not safe for concurrent use.
+type Builder struct {
+	buf   []byte
+	parts int
+}
+
+// Grow ensures the buffer has room for at least n more bytes without reallocating, like
+// bytes.Buffer.Grow. Call it with the total size (see EncodedSize) before appending to avoid
+// every reallocation. Panics if n is negative.
+func (b *Builder) Grow(n int) {
+	if n < 0 {
+		panic("lexkey.Builder.Grow: negative count")
+	}
+	b.buf = slices.Grow(b.buf, n)
+}
+
+// Append encodes part after the parts already appended, preceded by a Separator unless it is
+// the first part. On error the Builder is unchanged.
+func (b *Builder) Append(part any) error {
+	canon := [1]any{canonicalizePart(part)}
+	size := estimateSize(canon[:])
+	if b.parts > 0 {
+		size++ // Separator
+	}
+	b.Grow(size)
+	start := len(b.buf)
+	dst := b.buf[start : start+size]
+	if b.parts > 0 {
+		dst[0] = Separator
+		dst = dst[1:]
+	}
+	n, err := encodeInto(dst, canon[0])
+	if err != nil {
+		return fmt.Errorf("cannot append part %d (%T): %w", b.parts, part, err)
+	}
+	b.buf = b.buf[:start+size-len(dst)+n]
+	b.parts++
+	return nil
+}
+
+// Len returns the number of bytes appended so far.
+func (b *Builder) Len() int {
+	return len(b.buf)
+}
+
+// Key returns a copy of the key built so far, so the Builder can be reset and reused.
+func (b *Builder) Key() LexKey {
+	return append(LexKey{}, b.buf...)
+}
+
+// Reset empties the Builder, keeping its buffer for the next key.
+func (b *Builder) Reset() {
+	b.buf = b.buf[:0]
+	b.parts = 0
+}
@@ -0,0 +1,93 @@
+package lexkey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldBuildSameKeyAsNewLexKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		parts []any
+	}{
+		{"single part", []any{"tenant"}},
+		{"mixed types", []any{"tenant", 42, true, 1.5, uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")}},
+		{"empty first part", []any{"", "b"}},
+		{"nil in the middle", []any{"a", nil, time.Unix(0, 0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			expected, err := NewLexKey(tt.parts...)
+			require.NoError(t, err)
+			var b Builder
+
+			// Act
+			for _, p := range tt.parts {
+				require.NoError(t, b.Append(p))
+			}
+
+			// Assert
+			assert.Equal(t, expected, b.Key())
+			assert.Equal(t, len(expected), b.Len())
+		})
+	}
+}
+
+func TestShouldNotReallocateAfterGrowWithExactSize(t *testing.T) {
+	// Arrange
+	parts := []any{"tenant", int64(42), "orders", true}
+	size, err := EncodedSize(parts...)
+	require.NoError(t, err)
+	var b Builder
+
+	// Act
+	b.Grow(size)
+	before := cap(b.buf)
+	for _, p := range parts {
+		require.NoError(t, b.Append(p))
+	}
+
+	// Assert
+	assert.Equal(t, before, cap(b.buf))
+	assert.Equal(t, size, b.Len())
+}
+
+func TestShouldLeaveBuilderUnchangedWhenAppendFails(t *testing.T) {
+	// Arrange
+	var b Builder
+	require.NoError(t, b.Append("a"))
+
+	// Act
+	err := b.Append(map[string]int{})
+
+	// Assert
+	require.ErrorIs(t, err, ErrUnsupportedType)
+	require.NoError(t, b.Append("b"))
+	assert.Equal(t, Encode("a", "b"), b.Key())
+}
+
+func TestShouldReuseBuilderAfterReset(t *testing.T) {
+	// Arrange
+	var b Builder
+	require.NoError(t, b.Append("first"))
+	first := b.Key()
+
+	// Act
+	b.Reset()
+	require.NoError(t, b.Append("second"))
+
+	// Assert
+	assert.Equal(t, Encode("first"), first)
+	assert.Equal(t, Encode("second"), b.Key())
+}
+
+func TestShouldPanicWhenGrowIsNegative(t *testing.T) {
+	var b Builder
+	assert.Panics(t, func() { b.Grow(-1) })
+}
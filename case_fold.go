@@ -0,0 +1,34 @@
+package lexkey
+
+import "golang.org/x/text/cases"
+
+// CaseFold marks a string part to be encoded case-insensitively. The string is Unicode
+// case folded (not just ASCII-lowercased) before encoding, so "Hello", "HELLO" and "hello"
+// produce identical keys, as do "Straße" and "STRASSE". The folded form is what gets stored,
+// so the original casing cannot be decoded back.
+type CaseFold string
+
+// foldCase returns the Unicode case folding of s.
+func foldCase(s string) string {
+	return cases.Fold().String(s)
+}
+
+// CaseInsensitivePrefixRange returns the half-open bounds [lower, upper) of keys whose first
+// part is a string starting with prefix, ignoring case: with prefix "ab", keys for "abc",
+// "ABC" and "Abd" all fall inside. The prefix is case folded and the bounds cover the folded
+// space, so the matching parts must be stored folded too, e.g. Encode(CaseFold(name), id);
+// keys stored with their original casing are not matched reliably.
+//
+// Upper is ExclusiveUpperBound of the folded prefix, so it is correct even for invalid UTF-8,
+// which folding passes through unchanged and which may end in 0xFF bytes. An empty prefix
+// yields lower = Empty and upper = {EndMarker}, which covers every key whose first part is
+// valid UTF-8. A prefix of only 0xFF bytes has no upper bound; upper is then nil, meaning
+// scan to the end of the keyspace.
+func CaseInsensitivePrefixRange(prefix string) (lower, upper LexKey) {
+	folded := foldCase(prefix)
+	if folded == "" {
+		return LexKey{}, LexKey{EndMarker}
+	}
+	upper, _ = ExclusiveUpperBound(LexKey(folded))
+	return LexKey(folded), upper
+}
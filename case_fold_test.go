@@ -0,0 +1,108 @@
+package lexkey
+
+import (
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldEncodeCaseFoldedStringsIdentically(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"ascii", "Hello", "hello"},
+		{"ascii upper", "HELLO", "hello"},
+		{"german sharp s", "Straße", "STRASSE"},
+		{"greek final sigma", "ΣΊΣΥΦΟΣ", "σίσυφος"},
+		{"cyrillic", "ПРИВЕТ", "привет"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			a := Encode("idx", CaseFold(tt.a))
+			b := Encode("idx", CaseFold(tt.b))
+
+			// Assert
+			assert.Equal(t, a, b)
+		})
+	}
+}
+
+func TestShouldEncodeCaseFoldAsFoldedString(t *testing.T) {
+	// Act / Assert
+	assert.Equal(t, Encode("hello"), Encode(CaseFold("HeLLo")))
+	assert.NotEqual(t, Encode("Hello"), Encode(CaseFold("Hello")))
+}
+
+func TestShouldSortCaseFoldedStringsIgnoringCase(t *testing.T) {
+	// Arrange
+	apple := Encode(CaseFold("apple"))
+	banana := Encode(CaseFold("BANANA"))
+
+	// Act / Assert: plain encoding would sort "BANANA" before "apple"
+	assert.Less(t, Compare(apple, banana), 0)
+	assert.Greater(t, Compare(Encode("apple"), Encode("BANANA")), 0)
+}
+
+func TestShouldCoverMixedCaseKeysStoredFoldedInPrefixRange(t *testing.T) {
+	// Arrange
+	lower, upper := CaseInsensitivePrefixRange("AB")
+	bounds := Bounds{Lower: lower, Upper: upper}
+	inside := []string{"ab", "abc", "ABC", "Abc", "aBz", "ABZZZ"}
+	outside := []string{"a", "aa", "ac", "AC", "b", "xab"}
+
+	// Act / Assert
+	for _, s := range inside {
+		assert.True(t, bounds.Contains(Encode(CaseFold(s), int64(1))), "%q should be in range", s)
+	}
+	for _, s := range outside {
+		assert.False(t, bounds.Contains(Encode(CaseFold(s), int64(1))), "%q should be out of range", s)
+	}
+}
+
+func TestShouldFoldUnicodePrefixInCaseInsensitivePrefixRange(t *testing.T) {
+	// Arrange
+	lower, upper := CaseInsensitivePrefixRange("STRASS")
+	bounds := Bounds{Lower: lower, Upper: upper}
+
+	// Act / Assert
+	assert.Equal(t, LexKey("strass"), lower)
+	assert.Equal(t, LexKey("strast"), upper)
+	assert.True(t, bounds.Contains(Encode(CaseFold("Straße"))))
+}
+
+func TestShouldCoverAllFoldedKeysForEmptyPrefix(t *testing.T) {
+	// Arrange
+	lower, upper := CaseInsensitivePrefixRange("")
+	bounds := Bounds{Lower: lower, Upper: upper}
+
+	// Act / Assert
+	assert.True(t, bounds.Contains(Encode(CaseFold(""))))
+	assert.True(t, bounds.Contains(Encode(CaseFold("zzz"), int64(1))))
+	assert.True(t, bounds.Contains(Encode(CaseFold("\U0010FFFF"))))
+}
+
+func TestShouldCarryPastTrailingEndMarkerBytesInCaseInsensitivePrefixRange(t *testing.T) {
+	// Arrange: invalid UTF-8 passes through folding unchanged
+	lower, upper := CaseInsensitivePrefixRange("A\xff")
+	bounds := Bounds{Lower: lower, Upper: upper}
+
+	// Act / Assert
+	test.AssertHexEqual(t, "61ff", lower)
+	test.AssertHexEqual(t, "62", upper)
+	assert.True(t, bounds.Contains(Encode(CaseFold("a\xff"), int64(1))))
+	assert.True(t, bounds.Contains(Encode(CaseFold("a\xff\xffz"))))
+	assert.False(t, bounds.Contains(Encode(CaseFold("b"))))
+}
+
+func TestShouldLeaveUpperOpenForAllEndMarkerPrefix(t *testing.T) {
+	// Act
+	lower, upper := CaseInsensitivePrefixRange("\xff\xff")
+
+	// Assert
+	test.AssertHexEqual(t, "ffff", lower)
+	assert.Nil(t, upper)
+}
@@ -0,0 +1,29 @@
+package lexkey
+
+import (
+	"sync"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// collatorPools caches a *sync.Pool of collators per language tag; collators are not safe
+// for concurrent use and are costly to build.
+var collatorPools sync.Map // language.Tag -> *sync.Pool
+
+// EncodeCollated returns the collation sort key of s for the given locale, so byte-wise
+// ordering of the result matches the locale's sort order (e.g. Swedish sorts å, ä, ö after z).
+//
+// Collation keys are one-way: the original string cannot be decoded from them. They may
+// contain 0x00 bytes, so use the result as the last part of a composite key (e.g. with Concat).
+func EncodeCollated(tag language.Tag, s string) LexKey {
+	pool, _ := collatorPools.LoadOrStore(tag, &sync.Pool{
+		New: func() any { return collate.New(tag) },
+	})
+	p := pool.(*sync.Pool)
+	c := p.Get().(*collate.Collator)
+	defer p.Put(c)
+	var buf collate.Buffer
+	key := c.KeyFromString(&buf, s)
+	return append(LexKey{}, key...)
+}
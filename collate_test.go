@@ -0,0 +1,44 @@
+package lexkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestShouldSortSwedishLettersAfterZWhenCollated(t *testing.T) {
+	// Arrange
+	words := []string{"z", "å", "ä", "ö"}
+
+	// Act
+	keys := make([]LexKey, len(words))
+	for i, w := range words {
+		keys[i] = EncodeCollated(language.Swedish, w)
+	}
+
+	// Assert
+	for i := 0; i < len(keys)-1; i++ {
+		assert.Less(t, Compare(keys[i], keys[i+1]), 0, "%q should sort before %q", words[i], words[i+1])
+	}
+}
+
+func TestShouldDifferFromByteOrderForSwedish(t *testing.T) {
+	// Arrange: UTF-8 bytes put "ä" (c3 a4) before "å" (c3 a5)
+	rawA, rawAo := Encode("ä"), Encode("å")
+	colA, colAo := EncodeCollated(language.Swedish, "ä"), EncodeCollated(language.Swedish, "å")
+
+	// Act / Assert
+	assert.Less(t, Compare(rawA, rawAo), 0)
+	assert.Greater(t, Compare(colA, colAo), 0)
+}
+
+func TestShouldProduceStableCollationKeys(t *testing.T) {
+	// Act
+	a := EncodeCollated(language.German, "Äpfel")
+	b := EncodeCollated(language.German, "Äpfel")
+
+	// Assert
+	assert.Equal(t, a, b)
+	assert.NotEmpty(t, a)
+}
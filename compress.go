@@ -0,0 +1,63 @@
+package lexkey
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Front coding: each key is written as the length of the prefix it shares with the previous
+// key and the length of the rest, both as uvarints, followed by the rest's bytes. The first
+// key shares nothing. Sorted keys share long prefixes with their neighbours, so a sorted set
+// of similar keys (e.g. one partition's rows) shrinks to little more than its suffixes.
+
+// CompressSorted front-codes keys into a single buffer for compact storage; DecompressSorted
+// reverses it. Keys in any order round-trip, but only sorted keys compress well, so sort them
+// first (see Sort). Empty and nil keys are allowed and decompress as empty keys.
+func CompressSorted(keys []LexKey) []byte {
+	var out []byte
+	var prev LexKey
+	for _, key := range keys {
+		shared, n := 0, min(len(prev), len(key))
+		for shared < n && prev[shared] == key[shared] {
+			shared++
+		}
+		out = binary.AppendUvarint(out, uint64(shared))
+		out = binary.AppendUvarint(out, uint64(len(key)-shared))
+		out = append(out, key[shared:]...)
+		prev = key
+	}
+	return out
+}
+
+// DecompressSorted decodes a buffer written by CompressSorted. Each key is a fresh copy.
+// Returns an error if the buffer is truncated or a shared prefix is longer than the
+// previous key.
+func DecompressSorted(b []byte) ([]LexKey, error) {
+	var keys []LexKey
+	var prev LexKey
+	for len(b) > 0 {
+		shared, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, fmt.Errorf("cannot decompress key %d: invalid prefix length", len(keys))
+		}
+		b = b[n:]
+		suffix, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, fmt.Errorf("cannot decompress key %d: invalid suffix length", len(keys))
+		}
+		b = b[n:]
+		if shared > uint64(len(prev)) {
+			return nil, fmt.Errorf("cannot decompress key %d: shared prefix %d exceeds previous key length %d", len(keys), shared, len(prev))
+		}
+		if suffix > uint64(len(b)) {
+			return nil, fmt.Errorf("cannot decompress key %d: need %d suffix bytes, have %d", len(keys), suffix, len(b))
+		}
+		key := make(LexKey, 0, int(shared)+int(suffix))
+		key = append(key, prev[:shared]...)
+		key = append(key, b[:suffix]...)
+		b = b[suffix:]
+		keys = append(keys, key)
+		prev = key
+	}
+	return keys, nil
+}
@@ -0,0 +1,102 @@
+package lexkey
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldRoundTripSortedKeysThroughCompression(t *testing.T) {
+	// Arrange
+	keys := []LexKey{
+		Empty,
+		Encode("tenant", "orders", int64(1)),
+		Encode("tenant", "orders", int64(2)),
+		Encode("tenant", "orders", int64(2), "line"),
+		Encode("tenant", "users", "alice"),
+		Encode("tenant", "users", "bob"),
+		Encode("zeta"),
+	}
+
+	// Act
+	got, err := DecompressSorted(CompressSorted(keys))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, keys, got)
+}
+
+func TestShouldShrinkSimilarKeysWithCompression(t *testing.T) {
+	// Arrange
+	keys := make([]LexKey, 1000)
+	raw := 0
+	for i := range keys {
+		keys[i] = Encode("tenant-0001", "orders", fmt.Sprintf("order-%06d", i))
+		raw += len(keys[i])
+	}
+
+	// Act
+	compressed := CompressSorted(keys)
+
+	// Assert: each key shares all but its last few bytes with the previous one
+	assert.Less(t, len(compressed), raw/4)
+	got, err := DecompressSorted(compressed)
+	require.NoError(t, err)
+	assert.Equal(t, keys, got)
+}
+
+func TestShouldFrontCodeAgainstPreviousKey(t *testing.T) {
+	// Act
+	compressed := CompressSorted([]LexKey{LexKey("abc"), LexKey("abd")})
+
+	// Assert: (0, 3, "abc"), (2, 1, "d")
+	test.AssertHexEqual(t, "0003616263020164", compressed)
+}
+
+func TestShouldRoundTripUnsortedKeysThroughCompression(t *testing.T) {
+	// Arrange
+	keys := []LexKey{Encode("b"), Encode("a", "x"), Encode("a")}
+
+	// Act
+	got, err := DecompressSorted(CompressSorted(keys))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, keys, got)
+}
+
+func TestShouldRejectMalformedCompressedKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		errMsg string
+	}{
+		{"truncated prefix length", []byte{0x80}, "invalid prefix length"},
+		{"missing suffix length", []byte{0x00}, "invalid suffix length"},
+		{"shared prefix too long", []byte{0x01, 0x00}, "exceeds previous key length"},
+		{"truncated suffix", []byte{0x00, 0x03, 'a'}, "need 3 suffix bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := DecompressSorted(tt.input)
+
+			// Assert
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestShouldDecompressEmptyBufferToNoKeys(t *testing.T) {
+	// Act
+	keys, err := DecompressSorted(CompressSorted(nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
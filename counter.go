@@ -0,0 +1,52 @@
+package lexkey
+
+import "fmt"
+
+// Counter generates strictly increasing keys of unbounded size for auto-increment IDs.
+// The zero value is ready to use and its first Next is the key for 1.
+//
+// Each key is the byte length of the value as a sortable uvarint (see
+// EncodeUvarintSortable) followed by the value in minimal big-endian bytes, so a longer
+// value always sorts after a shorter one: 255 (01 ff) < 256 (02 01 00). Unlike a uint64
+// the counter never wraps; past 2^64-1 it simply grows another byte.
+//
+// A Counter is not safe for concurrent use.
+type Counter struct {
+	value []byte // big-endian, no leading zero bytes; empty for zero
+}
+
+// ResumeCounter returns a Counter whose next key follows last, a key previously returned by
+// Counter.Next (e.g. the largest one persisted before a restart). It returns an error if last
+// is not a valid counter key.
+func ResumeCounter(last LexKey) (*Counter, error) {
+	size, n, err := DecodeUvarintSortable(last)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resume counter: %w", err)
+	}
+	value := last[n:]
+	if uint64(len(value)) != size {
+		return nil, fmt.Errorf("cannot resume counter: length prefix %d does not match %d value bytes", size, len(value))
+	}
+	if size > 0 && value[0] == 0 {
+		return nil, fmt.Errorf("cannot resume counter: non-minimal value %x", []byte(value))
+	}
+	return &Counter{value: append([]byte(nil), value...)}, nil
+}
+
+// Next increments the counter and returns the key for its new value. Each key sorts strictly
+// after every key returned before it. The result never aliases the counter's state.
+func (c *Counter) Next() LexKey {
+	i := len(c.value) - 1
+	for ; i >= 0 && c.value[i] == 0xFF; i-- {
+		c.value[i] = 0
+	}
+	if i >= 0 {
+		c.value[i]++
+	} else {
+		c.value = append([]byte{1}, c.value...)
+	}
+	prefix := EncodeUvarintSortable(uint64(len(c.value)))
+	key := make(LexKey, len(prefix)+len(c.value))
+	copy(key[copy(key, prefix):], c.value)
+	return key
+}
@@ -0,0 +1,93 @@
+package lexkey
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldGenerateStrictlyIncreasingCounterKeys(t *testing.T) {
+	// Arrange: cross the 1-, 2- and 3-byte boundaries
+	var c Counter
+	prev := c.Next()
+
+	// Act / Assert
+	for i := 2; i <= 70000; i++ {
+		next := c.Next()
+		require.Negative(t, Compare(prev, next), "key %d (%x) should sort after %x", i, []byte(next), []byte(prev))
+		prev = next
+	}
+}
+
+func TestShouldEncodeCounterLengthBoundaries(t *testing.T) {
+	// Arrange
+	var c Counter
+
+	// Act
+	first := c.Next()
+	for range 253 {
+		c.Next()
+	}
+	at255 := c.Next()
+	at256 := c.Next()
+
+	// Assert
+	test.AssertHexEqual(t, "0101", first)
+	test.AssertHexEqual(t, "01ff", at255)
+	test.AssertHexEqual(t, "020100", at256)
+}
+
+func TestShouldGrowCounterPastUint64WithoutWrapping(t *testing.T) {
+	// Arrange
+	c, err := ResumeCounter(LexKey{0x08, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe})
+	require.NoError(t, err)
+
+	// Act
+	maxUint64 := c.Next()
+	beyond := c.Next()
+
+	// Assert
+	test.AssertHexEqual(t, "08ffffffffffffffff", maxUint64)
+	test.AssertHexEqual(t, "09010000000000000000", beyond)
+	assert.Negative(t, Compare(maxUint64, beyond))
+}
+
+func TestShouldResumeCounterAfterLastKey(t *testing.T) {
+	// Arrange
+	var original Counter
+	var last LexKey
+	for range 1000 {
+		last = original.Next()
+	}
+
+	// Act
+	resumed, err := ResumeCounter(last)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(original.Next(), resumed.Next()))
+}
+
+func TestShouldRejectInvalidCounterKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		key  LexKey
+	}{
+		{"empty", LexKey{}},
+		{"length mismatch", LexKey{0x02, 0x01}},
+		{"leading zero", LexKey{0x02, 0x00, 0x01}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := ResumeCounter(tt.key)
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
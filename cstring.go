@@ -0,0 +1,18 @@
+package lexkey
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EncodeCString encodes s as a string part after checking that it contains no 0x00 byte, like
+// a C string. A null-free string cannot be confused with the Separator that follows it, so
+// keys built from such parts split back unambiguously (see Segments and PrefixParts) without
+// any escaping. Use it for identifiers and other strings known to be null-free; the bytes are
+// the same as Encode(s).
+func EncodeCString(s string) (LexKey, error) {
+	if i := strings.IndexByte(s, 0x00); i >= 0 {
+		return nil, fmt.Errorf("cannot encode C string: embedded 0x00 at offset %d", i)
+	}
+	return Encode(s), nil
+}
@@ -0,0 +1,40 @@
+package lexkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldEncodeNullFreeCString(t *testing.T) {
+	// Act
+	key, err := EncodeCString("orders_v2")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Encode("orders_v2"), key)
+}
+
+func TestShouldRejectCStringWithEmbeddedNull(t *testing.T) {
+	// Act
+	_, err := EncodeCString("bad\x00name")
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "offset 3")
+}
+
+func TestShouldSplitKeysBuiltFromCStrings(t *testing.T) {
+	// Arrange
+	a, errA := EncodeCString("tenant")
+	b, errB := EncodeCString("orders")
+	require.NoError(t, errA)
+	require.NoError(t, errB)
+
+	// Act
+	segments := Concat(a, b).Segments()
+
+	// Assert
+	assert.Equal(t, [][]byte{[]byte("tenant"), []byte("orders")}, segments)
+}
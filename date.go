@@ -0,0 +1,21 @@
+package lexkey
+
+import "time"
+
+// secondsPerDay is the length of a UTC calendar day; UTC has no daylight saving shifts.
+const secondsPerDay = 24 * 60 * 60
+
+// EncodeDate encodes the UTC calendar date of t, ignoring the time of day, as the number of
+// days since 1970-01-01 using the int64 encoding. All times on the same UTC day produce the
+// same key, and dates sort chronologically, including dates before 1970.
+// The day is that of t.UTC(); to key by a local calendar date instead, pass
+// time.Date(y, m, d, 0, 0, 0, 0, time.UTC) built from the local date.
+func EncodeDate(t time.Time) LexKey {
+	return Encode(daysSinceEpoch(t))
+}
+
+// daysSinceEpoch returns the number of whole UTC days between 1970-01-01 and t's UTC date.
+func daysSinceEpoch(t time.Time) int64 {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / secondsPerDay
+}
@@ -0,0 +1,56 @@
+package lexkey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldEncodeSameUTCDayEqually(t *testing.T) {
+	// Arrange
+	morning := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	night := time.Date(2025, 3, 1, 23, 59, 59, 999999999, time.UTC)
+	sameInstantElsewhere := time.Date(2025, 3, 2, 1, 0, 0, 0, time.FixedZone("", 2*60*60)) // 23:00 UTC on March 1
+
+	// Act / Assert
+	assert.Equal(t, EncodeDate(morning), EncodeDate(night))
+	assert.Equal(t, EncodeDate(morning), EncodeDate(sameInstantElsewhere))
+}
+
+func TestShouldEncodeDateAsDaysSinceEpoch(t *testing.T) {
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected string
+	}{
+		{"epoch", time.Date(1970, 1, 1, 12, 0, 0, 0, time.UTC), "8000000000000000"},
+		{"day after epoch", time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC), "8000000000000001"},
+		{"day before epoch", time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), "7fffffffffffffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act / Assert
+			test.AssertHexEqual(t, tt.expected, EncodeDate(tt.at))
+		})
+	}
+}
+
+func TestShouldSortDatesChronologically(t *testing.T) {
+	// Arrange
+	dates := []time.Time{
+		time.Date(1900, 1, 1, 23, 0, 0, 0, time.UTC),
+		time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2100, 12, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Act / Assert
+	for i := 1; i < len(dates); i++ {
+		assert.Negative(t, Compare(EncodeDate(dates[i-1]), EncodeDate(dates[i])))
+	}
+}
@@ -0,0 +1,199 @@
+package lexkey
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Decimal is a fixed-point decimal value equal to Coefficient × 10^Exponent,
+// e.g. {Coefficient: 150, Exponent: -2} is 1.50. Convert other decimal types
+// (such as shopspring/decimal) by copying their coefficient and exponent.
+//
+// Decimals encode by numeric value, independent of scale: 1, 1.0 and 1.00 produce
+// identical keys, and 1.5 sorts between 1 and 2.
+type Decimal struct {
+	Coefficient int64
+	Exponent    int32
+}
+
+// Decimal sign bytes; negative < zero < positive.
+const (
+	decimalNegative = 0x01
+	decimalZero     = 0x02
+	decimalPositive = 0x03
+)
+
+// decimalParts normalizes d into its significant digits (no trailing zeros) and the
+// adjusted exponent of the leading digit, so value = 0.d1d2... × 10^(adjusted+1).
+func decimalParts(d Decimal) (negative bool, digits string, adjusted int32, err error) {
+	mag := uint64(d.Coefficient)
+	if d.Coefficient < 0 {
+		negative = true
+		mag = -mag
+	}
+	exp := int64(d.Exponent)
+	for mag%10 == 0 {
+		mag /= 10
+		exp++
+	}
+	digits = strconv.FormatUint(mag, 10)
+	adj := exp + int64(len(digits)) - 1
+	if adj < math.MinInt32 || adj > math.MaxInt32 {
+		return false, "", 0, fmt.Errorf("decimal exponent %d out of range", adj)
+	}
+	return negative, digits, int32(adj), nil
+}
+
+// decimalSize returns the encoded size of d; see encodeDecimal.
+func decimalSize(d Decimal) int {
+	if d.Coefficient == 0 {
+		return 1
+	}
+	negative, digits, _, err := decimalParts(d)
+	if err != nil {
+		return 1 // will error during encoding
+	}
+	return decimalDigitsSize(negative, digits)
+}
+
+// encodeDecimal writes the order-preserving encoding of d into dst:
+//   - zero: 0x02
+//   - positive: 0x03, adjusted exponent as sign-flipped big-endian int32, ASCII digits
+//   - negative: 0x01, bitwise-inverted exponent, inverted digits, then a 0xFF terminator
+//
+// Digits carry no trailing zeros, so the scale of the input does not affect the bytes.
+func encodeDecimal(dst []byte, d Decimal) (int, error) {
+	if d.Coefficient == 0 {
+		dst[0] = decimalZero
+		return 1, nil
+	}
+	negative, digits, adjusted, err := decimalParts(d)
+	if err != nil {
+		return 0, err
+	}
+	return encodeDecimalDigits(dst, negative, digits, adjusted)
+}
+
+// decimalDigitsSize returns the encoded size of a non-zero value with the given digits.
+func decimalDigitsSize(negative bool, digits string) int {
+	if negative {
+		return 1 + 4 + len(digits) + 1
+	}
+	return 1 + 4 + len(digits)
+}
+
+// encodeDecimalDigits writes a non-zero value given as normalized significant digits and
+// the adjusted exponent of the leading digit; see encodeDecimal.
+func encodeDecimalDigits(dst []byte, negative bool, digits string, adjusted int32) (int, error) {
+	var exp [4]byte
+	if err := putLexInt32(exp[:], adjusted); err != nil {
+		return 0, err
+	}
+	if !negative {
+		dst[0] = decimalPositive
+		copy(dst[1:], exp[:])
+		n := 5 + copy(dst[5:], digits)
+		return n, nil
+	}
+	dst[0] = decimalNegative
+	for i, b := range exp {
+		dst[1+i] = ^b
+	}
+	for i := 0; i < len(digits); i++ {
+		dst[5+i] = ^digits[i]
+	}
+	n := 5 + len(digits)
+	dst[n] = 0xFF
+	return n + 1, nil
+}
+
+// decimalLen returns the length of the decimal encoding at the start of b: 1 for zero, the
+// digits running to the first non-digit byte for positive values, and up to the 0xFF
+// terminator for negative ones. Malformed input yields a length that fails decoding.
+func decimalLen(b []byte) int {
+	if len(b) == 0 {
+		return 1
+	}
+	switch b[0] {
+	case decimalPositive:
+		n := 5
+		for n < len(b) && b[n] >= '0' && b[n] <= '9' {
+			n++
+		}
+		return n
+	case decimalNegative:
+		if len(b) > 5 {
+			if i := bytes.IndexByte(b[5:], 0xFF); i >= 0 {
+				return 5 + i + 1
+			}
+		}
+		return len(b) + 1
+	}
+	return 1
+}
+
+// decodeDecimalDigits reverses encodeDecimalDigits for a complete non-zero segment,
+// returning the significant digits and the adjusted exponent of the leading digit.
+func decodeDecimalDigits(seg []byte) (negative bool, digits string, adjusted int32, err error) {
+	if len(seg) < 6 || (seg[0] != decimalPositive && seg[0] != decimalNegative) {
+		return false, "", 0, fmt.Errorf("invalid decimal encoding %x", seg)
+	}
+	negative = seg[0] == decimalNegative
+	var exp [4]byte
+	copy(exp[:], seg[1:5])
+	body := seg[5:]
+	if negative {
+		if body[len(body)-1] != 0xFF {
+			return false, "", 0, fmt.Errorf("invalid decimal encoding %x: missing terminator", seg)
+		}
+		body = body[:len(body)-1]
+		for i := range exp {
+			exp[i] = ^exp[i]
+		}
+	}
+	buf := make([]byte, len(body))
+	for i, c := range body {
+		if negative {
+			c = ^c
+		}
+		if c < '0' || c > '9' || (i == 0 && c == '0') {
+			return false, "", 0, fmt.Errorf("invalid decimal digit byte 0x%02x", body[i])
+		}
+		buf[i] = c
+	}
+	if len(buf) == 0 {
+		return false, "", 0, fmt.Errorf("invalid decimal encoding %x: no digits", seg)
+	}
+	adjusted, err = getLexInt32(exp[:])
+	if err != nil {
+		return false, "", 0, err
+	}
+	return negative, string(buf), adjusted, nil
+}
+
+// decodeDecimal decodes a Decimal segment. Decimals encode by value, so the result is the
+// normalized form without trailing zeros: 1.50 decodes as {Coefficient: 15, Exponent: -1}.
+func decodeDecimal(seg []byte) (Decimal, error) {
+	if len(seg) == 1 && seg[0] == decimalZero {
+		return Decimal{}, nil
+	}
+	negative, digits, adjusted, err := decodeDecimalDigits(seg)
+	if err != nil {
+		return Decimal{}, err
+	}
+	exp := int64(adjusted) - int64(len(digits)) + 1
+	if exp < math.MinInt32 {
+		return Decimal{}, fmt.Errorf("decimal exponent %d out of range", exp)
+	}
+	text := digits
+	if negative {
+		text = "-" + digits
+	}
+	coeff, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("decimal coefficient %s out of range", text)
+	}
+	return Decimal{Coefficient: coeff, Exponent: int32(exp)}, nil
+}
@@ -0,0 +1,119 @@
+package lexkey
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldEncodeEqualDecimalsAcrossExponentsIdentically(t *testing.T) {
+	// Arrange
+	one := Encode(Decimal{Coefficient: 1, Exponent: 0})
+	oneTenths := Encode(Decimal{Coefficient: 10, Exponent: -1})
+	oneHundredths := Encode(Decimal{Coefficient: 100, Exponent: -2})
+	negOne := Encode(Decimal{Coefficient: -1, Exponent: 0})
+	negOneTenths := Encode(Decimal{Coefficient: -10, Exponent: -1})
+
+	// Act / Assert
+	assert.Equal(t, one, oneTenths)
+	assert.Equal(t, one, oneHundredths)
+	assert.Equal(t, negOne, negOneTenths)
+	assert.Equal(t, Encode(Decimal{}), Encode(Decimal{Coefficient: 0, Exponent: 5}))
+}
+
+func TestShouldSortDecimalsNumericallyAcrossExponents(t *testing.T) {
+	// Arrange: ascending numeric order with mixed exponents
+	values := []Decimal{
+		{Coefficient: math.MinInt64, Exponent: 0},
+		{Coefficient: -25, Exponent: 1},   // -250
+		{Coefficient: -2, Exponent: 0},    // -2
+		{Coefficient: -150, Exponent: -2}, // -1.5
+		{Coefficient: -1, Exponent: 0},    // -1
+		{Coefficient: -5, Exponent: -3},   // -0.005
+		{Coefficient: 0, Exponent: 0},     // 0
+		{Coefficient: 5, Exponent: -3},    // 0.005
+		{Coefficient: 1, Exponent: 0},     // 1
+		{Coefficient: 15, Exponent: -1},   // 1.5
+		{Coefficient: 1999, Exponent: -3}, // 1.999
+		{Coefficient: 2, Exponent: 0},     // 2
+		{Coefficient: 25, Exponent: 1},    // 250
+		{Coefficient: math.MaxInt64, Exponent: 0},
+	}
+
+	// Act / Assert
+	for i := 0; i < len(values)-1; i++ {
+		a, b := Encode(values[i]), Encode(values[i+1])
+		assert.Less(t, Compare(a, b), 0, "%+v should sort before %+v", values[i], values[i+1])
+	}
+}
+
+func TestShouldSortDecimalsInsideCompositeKeys(t *testing.T) {
+	// Arrange
+	a := Encode("price", Decimal{Coefficient: -1, Exponent: 0}, "x")
+	b := Encode("price", Decimal{Coefficient: -15, Exponent: -1}, "x")
+
+	// Act / Assert: -1.5 < -1 even though its digits are longer
+	assert.Less(t, Compare(b, a), 0)
+}
+
+func TestShouldEncodeDecimalBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Decimal
+		expected string
+	}{
+		{"zero", Decimal{}, "02"},
+		{"one", Decimal{Coefficient: 1}, "038000000031"},
+		{"one point five", Decimal{Coefficient: 15, Exponent: -1}, "03800000003135"},
+		{"negative one", Decimal{Coefficient: -1}, "017fffffffceff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			key, err := NewLexKey(tt.input)
+
+			// Assert
+			require.NoError(t, err)
+			test.AssertHexEqual(t, tt.expected, key)
+			assert.Len(t, key, EncodeSize(tt.input))
+		})
+	}
+}
+
+func TestShouldErrorWhenDecimalExponentOverflows(t *testing.T) {
+	// Act
+	_, err := NewLexKey(Decimal{Coefficient: 12345, Exponent: math.MaxInt32})
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestShouldRejectMalformedDecimalEncodings(t *testing.T) {
+	decimalType := reflect.TypeOf(Decimal{})
+	tests := []struct {
+		name  string
+		input LexKey
+	}{
+		{"invalid sign byte", LexKey{0x04}},
+		{"positive without digits", LexKey{0x03, 0x80, 0x00, 0x00, 0x00}},
+		{"positive with leading zero", LexKey{0x03, 0x80, 0x00, 0x00, 0x00, '0', '1'}},
+		{"negative without terminator", LexKey{0x01, 0x7f, 0xff, 0xff, 0xff, 0xce}},
+		{"negative with invalid digit", LexKey{0x01, 0x7f, 0xff, 0xff, 0xff, 0x01, 0xff}},
+		{"coefficient overflows int64", append(LexKey{0x03, 0x80, 0x00, 0x00, 0x13}, "12345678901234567890"...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := Decode(tt.input, decimalType)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
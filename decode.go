@@ -0,0 +1,389 @@
+package lexkey
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+var (
+	uuidType           = reflect.TypeOf(uuid.UUID{})
+	timeType           = reflect.TypeOf(time.Time{})
+	zonedTimeType      = reflect.TypeOf(ZonedTime{})
+	lengthPrefixedType = reflect.TypeOf(LengthPrefixed{})
+	float16Type        = reflect.TypeOf(Float16(0))
+	nonNegType         = reflect.TypeOf(NonNeg(0))
+	triBoolType        = reflect.TypeOf(TriBool(0))
+	decimalType        = reflect.TypeOf(Decimal{})
+	ratType            = reflect.TypeOf((*big.Rat)(nil))
+	addrType           = reflect.TypeOf(netip.Addr{})
+	lexKeyType         = reflect.TypeOf(LexKey{})
+	emptyStructType    = reflect.TypeOf(struct{}{})
+)
+
+// Decode decodes a key produced by NewLexKey back into typed values. The schema lists the
+// Go type of each part in order; a nil entry stands for a nil part.
+//
+// Fixed-width parts (numbers, bools, UUIDs, times) are read at their canonical width.
+// Variable-width parts (string, []byte, LexKey) extend to the next Separator, or to the end
+// of the key when last, so only the last variable-width part may contain 0x00 bytes.
+// LengthPrefixed, Decimal and *big.Rat parts carry their own length and may contain 0x00
+// bytes anywhere.
+// Named types are decoded by their underlying kind and converted back to the schema type;
+// narrower integers are range-checked.
+//
+// Every value decodes to one that encodes identically, and most decode exactly. The
+// exceptions are the normal forms the encoding keeps: time.Time decodes in UTC; ZonedTime
+// keeps its zone offset but not the zone name; Decimal drops trailing zeros (1.50 decodes as
+// 1.5); *big.Rat keeps RatDigits significant digits; -0.0 decodes as +0.0 and every NaN as
+// the canonical NaN; CaseFold and pointers decode as the folded string and the pointed-to
+// value. json.Number cannot be decoded.
+func Decode(key LexKey, schema ...reflect.Type) ([]any, error) {
+	if len(schema) == 0 {
+		return nil, errors.New("cannot decode LexKey: no schema provided")
+	}
+	values := make([]any, len(schema))
+	rest := []byte(key)
+	for i, t := range schema {
+		seg, next, err := nextSegment(rest, t, i == len(schema)-1)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode part %d (%v): %w", i, t, err)
+		}
+		v, err := decodeValue(seg, t)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode part %d (%v): %w", i, t, err)
+		}
+		values[i] = v
+		rest = next
+	}
+	return values, nil
+}
+
+// DecodeHex decodes a key from its hex form (as produced by ToHexString, e.g. from logs) and
+// then its parts with Decode. Invalid hex is reported as ErrInvalidHex. Use ParseHex first
+// for pasted input with a 0x prefix or whitespace.
+func DecodeHex(hexStr string, schema ...reflect.Type) ([]any, error) {
+	var key LexKey
+	if err := key.FromHexString(hexStr); err != nil {
+		return nil, err
+	}
+	return Decode(key, schema...)
+}
+
+// DecodeAt decodes only the part at index, using the schema to skip over earlier parts.
+// Returns an error if the index is out of range or the decoded value is not a T.
+func DecodeAt[T any](key LexKey, index int, schema ...reflect.Type) (T, error) {
+	var zero T
+	if index < 0 || index >= len(schema) {
+		return zero, fmt.Errorf("cannot decode LexKey: index %d out of range for %d-part schema", index, len(schema))
+	}
+	rest := []byte(key)
+	for i := 0; i <= index; i++ {
+		seg, next, err := nextSegment(rest, schema[i], i == len(schema)-1)
+		if err != nil {
+			return zero, fmt.Errorf("cannot decode part %d (%v): %w", i, schema[i], err)
+		}
+		if i < index {
+			rest = next
+			continue
+		}
+		v, err := decodeValue(seg, schema[i])
+		if err != nil {
+			return zero, fmt.Errorf("cannot decode part %d (%v): %w", i, schema[i], err)
+		}
+		typed, ok := v.(T)
+		if !ok {
+			return zero, fmt.Errorf("cannot decode part %d: got %T, want %T", i, v, zero)
+		}
+		return typed, nil
+	}
+	return zero, nil
+}
+
+// DecodeOne decodes the leading part of b as type t and reports how many bytes it consumed,
+// including the Separator after the part when one follows, so decodes can be chained over a
+// buffer: DecodeOne(b[consumed:], next). A variable-width part (string, []byte, LexKey)
+// extends to the first Separator, or to the end of b when there is none.
+func DecodeOne(b []byte, t reflect.Type) (value any, consumed int, err error) {
+	width, variable, err := partWidth(t)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot decode part (%v): %w", t, err)
+	}
+	var seg []byte
+	switch {
+	case variable:
+		seg, consumed = b, len(b)
+		if i := bytes.IndexByte(b, Separator); i >= 0 {
+			seg, consumed = b[:i], i+1
+		}
+	default:
+		width = delimitedWidth(b, t, width)
+		if len(b) < width {
+			return nil, 0, fmt.Errorf("cannot decode part (%v): need %d bytes, have %d", t, width, len(b))
+		}
+		seg, consumed = b[:width], width
+		if len(b) > width && b[width] == Separator {
+			consumed++
+		}
+	}
+	value, err = decodeValue(seg, t)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot decode part (%v): %w", t, err)
+	}
+	return value, consumed, nil
+}
+
+// DecodeAll decodes keys of one fixed-width schema concatenated without framing, as written
+// by appending NewLexKey results back to back. Each record is the schema's parts joined by
+// Separators, so every record has the same width. Returns an error if the schema has a
+// variable-width part (including LengthPrefixed) or b ends with a partial record.
+func DecodeAll(b []byte, schema []reflect.Type) ([][]any, error) {
+	size, err := recordWidth(schema)
+	if err != nil {
+		return nil, err
+	}
+	if len(b)%size != 0 {
+		return nil, fmt.Errorf("cannot decode records: %d trailing bytes after %d records of %d bytes", len(b)%size, len(b)/size, size)
+	}
+	records := make([][]any, 0, len(b)/size)
+	for off := 0; off < len(b); off += size {
+		values, err := Decode(b[off:off+size], schema...)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode record %d: %w", off/size, err)
+		}
+		records = append(records, values)
+	}
+	return records, nil
+}
+
+// recordWidth returns the encoded width of a key with the given fixed-width schema,
+// including the Separators between parts.
+func recordWidth(schema []reflect.Type) (int, error) {
+	if len(schema) == 0 {
+		return 0, errors.New("cannot decode records: no schema provided")
+	}
+	size := len(schema) - 1
+	for i, t := range schema {
+		width, variable, err := partWidth(t)
+		if err != nil {
+			return 0, fmt.Errorf("cannot decode part %d (%v): %w", i, t, err)
+		}
+		if variable || selfDelimited(t) {
+			return 0, fmt.Errorf("cannot decode records: part %d (%v) is not fixed-width", i, t)
+		}
+		size += width
+	}
+	return size, nil
+}
+
+// DecodeString decodes a single encoded string part. Strings are stored as their raw bytes,
+// so any byte sequence decodes; with checkUTF8 set, a segment that is not valid UTF-8
+// (e.g. from a []byte part) is rejected with ErrInvalidUTF8 instead, before it reaches logs
+// or JSON as mojibake.
+func DecodeString(b []byte, checkUTF8 bool) (string, error) {
+	if checkUTF8 && !utf8.Valid(b) {
+		return "", fmt.Errorf("cannot decode string %x: %w", b, ErrInvalidUTF8)
+	}
+	return string(b), nil
+}
+
+// DecodeBool decodes a single encoded bool part. A 0x00 byte is false, but it is also how
+// nil encodes, so only use DecodeBool where the schema declares a bool. Returns an error
+// unless b is exactly one byte of 0x00 or 0x01.
+func DecodeBool(b []byte) (bool, error) {
+	if len(b) != 1 {
+		return false, fmt.Errorf("invalid bool length %d", len(b))
+	}
+	switch b[0] {
+	case 0x00:
+		return false, nil
+	case 0x01:
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid bool byte 0x%02x", b[0])
+}
+
+// partWidth returns the encoded width of a part of type t, or variable=true for
+// variable-width types.
+func partWidth(t reflect.Type) (width int, variable bool, err error) {
+	switch t {
+	case nil, emptyStructType:
+		return 1, false, nil
+	case uuidType, addrType:
+		return 16, false, nil
+	case timeType:
+		return 8, false, nil
+	case zonedTimeType:
+		return zonedTimeSize, false, nil
+	case lengthPrefixedType:
+		return lengthPrefixSize, false, nil // plus the prefixed length, see delimitedWidth
+	case decimalType, ratType:
+		return 1, false, nil // at least the sign byte, see delimitedWidth
+	case float16Type:
+		return 2, false, nil
+	case triBoolType:
+		return 1, false, nil
+	case lexKeyType:
+		return 0, true, nil
+	case reflect.TypeOf(json.Number("")), reflect.TypeOf(JSONNumberAsInt("")), reflect.TypeOf(JSONNumberAsFloat("")):
+		return 0, false, fmt.Errorf("%w: encoding is not reversible", &UnsupportedTypeError{Type: t})
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return 0, true, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return 0, true, nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return 8, false, nil
+	case reflect.Bool:
+		return 1, false, nil
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return t.Len(), false, nil
+		}
+	}
+	return 0, false, &UnsupportedTypeError{Type: t}
+}
+
+// selfDelimited reports whether parts of type t carry their own length, so partWidth only
+// returns their minimum width.
+func selfDelimited(t reflect.Type) bool {
+	return t == lengthPrefixedType || t == decimalType || t == ratType
+}
+
+// delimitedWidth returns the full width of a self-delimited part of type t at the start of b,
+// or width unchanged for other types.
+func delimitedWidth(b []byte, t reflect.Type, width int) int {
+	switch t {
+	case lengthPrefixedType:
+		if len(b) >= lengthPrefixSize {
+			return lengthPrefixedWidth(b)
+		}
+	case decimalType, ratType:
+		return decimalLen(b)
+	}
+	return width
+}
+
+// nextSegment splits the leading part of type t off b, returning the part's bytes and the
+// remainder after its trailing Separator. The last part must consume b exactly.
+func nextSegment(b []byte, t reflect.Type, last bool) (seg, rest []byte, err error) {
+	width, variable, err := partWidth(t)
+	if err != nil {
+		return nil, nil, err
+	}
+	if variable {
+		if last {
+			return b, nil, nil
+		}
+		i := bytes.IndexByte(b, Separator)
+		if i < 0 {
+			return nil, nil, errors.New("missing separator")
+		}
+		return b[:i], b[i+1:], nil
+	}
+	width = delimitedWidth(b, t, width)
+	if len(b) < width {
+		return nil, nil, fmt.Errorf("need %d bytes, have %d", width, len(b))
+	}
+	seg, rest = b[:width], b[width:]
+	if last {
+		if len(rest) != 0 {
+			return nil, nil, fmt.Errorf("%d trailing bytes", len(rest))
+		}
+		return seg, nil, nil
+	}
+	if len(rest) == 0 || rest[0] != Separator {
+		return nil, nil, errors.New("missing separator")
+	}
+	return seg, rest[1:], nil
+}
+
+// decodeValue decodes a single part's bytes into a value of type t.
+func decodeValue(seg []byte, t reflect.Type) (any, error) {
+	switch t {
+	case nil:
+		if seg[0] != Separator {
+			return nil, fmt.Errorf("invalid nil byte 0x%02x", seg[0])
+		}
+		return nil, nil
+	case emptyStructType:
+		if seg[0] != EndMarker {
+			return nil, fmt.Errorf("invalid end marker byte 0x%02x", seg[0])
+		}
+		return struct{}{}, nil
+	case uuidType:
+		return uuid.FromBytes(seg)
+	case timeType:
+		n, err := getLexInt64(seg)
+		if err != nil {
+			return nil, err
+		}
+		return time.Unix(0, n).UTC(), nil
+	case zonedTimeType:
+		return decodeZonedTime(seg)
+	case lengthPrefixedType:
+		return LengthPrefixed(append([]byte{}, seg[lengthPrefixSize:]...)), nil
+	case float16Type:
+		return decodeFloat16Bits(binary.BigEndian.Uint16(seg)), nil
+	case nonNegType:
+		return decodeNonNeg(binary.BigEndian.Uint64(seg))
+	case triBoolType:
+		return decodeTriBool(seg)
+	case decimalType:
+		return decodeDecimal(seg)
+	case ratType:
+		return decodeRat(seg)
+	case addrType:
+		return decodeAddr(seg)
+	}
+	var rv reflect.Value
+	switch t.Kind() {
+	case reflect.String:
+		rv = reflect.ValueOf(string(seg))
+	case reflect.Slice:
+		rv = reflect.ValueOf(append([]byte{}, seg...))
+	case reflect.Bool:
+		b, err := DecodeBool(seg)
+		if err != nil {
+			return nil, err
+		}
+		rv = reflect.ValueOf(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := getLexInt64(seg)
+		if err != nil {
+			return nil, err
+		}
+		if reflect.Zero(t).OverflowInt(n) {
+			return nil, fmt.Errorf("value %d overflows %v", n, t)
+		}
+		rv = reflect.ValueOf(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := binary.BigEndian.Uint64(seg)
+		if reflect.Zero(t).OverflowUint(n) {
+			return nil, fmt.Errorf("value %d overflows %v", n, t)
+		}
+		rv = reflect.ValueOf(n)
+	case reflect.Float32, reflect.Float64:
+		rv = reflect.ValueOf(decodeFloat64Bits(binary.BigEndian.Uint64(seg)))
+	case reflect.Array:
+		rv = reflect.New(t).Elem()
+		reflect.Copy(rv, reflect.ValueOf(seg))
+	default:
+		return nil, &UnsupportedTypeError{Type: t}
+	}
+	return rv.Convert(t).Interface(), nil
+}
@@ -0,0 +1,369 @@
+package lexkey
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldDecodeMixedTypeKey(t *testing.T) {
+	// Arrange
+	id := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	ts := time.Unix(1700000000, 123).UTC()
+	key := Encode("user", id, int64(-42), uint32(7), 3.5, true, ts, time.Duration(9), []byte{1, 0, 2})
+	schema := []reflect.Type{
+		reflect.TypeOf(""), reflect.TypeOf(uuid.UUID{}), reflect.TypeOf(int64(0)), reflect.TypeOf(uint32(0)),
+		reflect.TypeOf(0.0), reflect.TypeOf(false), reflect.TypeOf(time.Time{}), reflect.TypeOf(time.Duration(0)),
+		reflect.TypeOf([]byte{}),
+	}
+
+	// Act
+	values, err := Decode(key, schema...)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{"user", id, int64(-42), uint32(7), 3.5, true, ts, time.Duration(9), []byte{1, 0, 2}}, values)
+}
+
+func TestShouldDecodeNamedAndMarkerTypes(t *testing.T) {
+	// Arrange
+	key := Encode(testStatus(2), testName("bob"), nil, struct{}{}, Float16(0x3C00), float32(1.25))
+	schema := []reflect.Type{
+		reflect.TypeOf(testStatus(0)), reflect.TypeOf(testName("")), nil, reflect.TypeOf(struct{}{}),
+		reflect.TypeOf(Float16(0)), reflect.TypeOf(float32(0)),
+	}
+
+	// Act
+	values, err := Decode(key, schema...)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{testStatus(2), testName("bob"), nil, struct{}{}, Float16(0x3C00), float32(1.25)}, values)
+}
+
+func TestShouldDecodeSpecialFloats(t *testing.T) {
+	// Arrange
+	key := Encode(math.Inf(-1), math.Copysign(0, -1), math.Inf(1), math.NaN())
+	f := reflect.TypeOf(0.0)
+
+	// Act
+	values, err := Decode(key, f, f, f, f)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, math.Inf(-1), values[0])
+	assert.Equal(t, 0.0, values[1])
+	assert.Equal(t, math.Inf(1), values[2])
+	assert.True(t, math.IsNaN(values[3].(float64)))
+}
+
+func TestShouldDecodeAtIndexOnly(t *testing.T) {
+	// Arrange
+	key := Encode("tenant", int64(1234), true)
+	schema := []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(int64(0)), reflect.TypeOf(false)}
+
+	// Act
+	rowID, err := DecodeAt[int64](key, 1, schema...)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), rowID)
+}
+
+func TestShouldErrorWhenDecodeAtTypeOrIndexIsWrong(t *testing.T) {
+	// Arrange
+	key := Encode("tenant", int64(1234), true)
+	schema := []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(int64(0)), reflect.TypeOf(false)}
+
+	// Act
+	_, typeErr := DecodeAt[string](key, 1, schema...)
+	_, indexErr := DecodeAt[bool](key, 3, schema...)
+	_, negErr := DecodeAt[string](key, -1, schema...)
+
+	// Assert
+	require.Error(t, typeErr)
+	require.Error(t, indexErr)
+	require.Error(t, negErr)
+}
+
+func TestShouldErrorWhenDecodingMalformedKeys(t *testing.T) {
+	i64 := reflect.TypeOf(int64(0))
+	str := reflect.TypeOf("")
+	tests := []struct {
+		name   string
+		key    LexKey
+		schema []reflect.Type
+	}{
+		{"no schema", Encode("a"), nil},
+		{"truncated fixed width", Encode(int64(1))[:4], []reflect.Type{i64}},
+		{"trailing bytes", append(Encode(int64(1)), 'x'), []reflect.Type{i64}},
+		{"missing separator after fixed", append(Encode(int64(1)), 'x'), []reflect.Type{i64, str}},
+		{"missing separator after variable", Encode("abc"), []reflect.Type{str, str}},
+		{"narrow overflow", Encode(int64(300)), []reflect.Type{reflect.TypeOf(int8(0))}},
+		{"unsigned overflow", Encode(uint64(300)), []reflect.Type{reflect.TypeOf(uint8(0))}},
+		{"invalid bool", LexKey{0x02}, []reflect.Type{reflect.TypeOf(false)}},
+		{"invalid nil", LexKey{0x01}, []reflect.Type{nil}},
+		{"unsupported type", Encode("a"), []reflect.Type{reflect.TypeOf(map[string]int{})}},
+		{"irreversible json number", Encode(int64(1)), []reflect.Type{reflect.TypeOf(JSONNumberAsInt(""))}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := Decode(tt.key, tt.schema...)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestShouldDecodeEmptyStringKey(t *testing.T) {
+	// Act
+	values, err := Decode(Encode(""), reflect.TypeOf(""))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{""}, values)
+}
+
+func TestShouldDecodeCalendarEnums(t *testing.T) {
+	// Arrange
+	key := Encode(time.March, time.Friday)
+
+	// Act
+	values, err := Decode(key, reflect.TypeOf(time.Month(0)), reflect.TypeOf(time.Weekday(0)))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{time.March, time.Friday}, values)
+}
+
+func TestShouldDecodeBoolBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expected bool
+		wantErr  bool
+	}{
+		{"false", []byte{0x00}, false, false},
+		{"true", []byte{0x01}, true, false},
+		{"out of range byte", []byte{0x02}, false, true},
+		{"end marker", []byte{0xff}, false, true},
+		{"empty", nil, false, true},
+		{"too long", []byte{0x00, 0x01}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			b, err := DecodeBool(tt.input)
+
+			// Assert
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, b)
+		})
+	}
+}
+
+func TestShouldResolveAmbiguousZeroByteBySchema(t *testing.T) {
+	// Arrange: false and nil encode identically
+	key := Encode("a", false)
+	require.Equal(t, Encode("a", nil), key)
+
+	// Act
+	asBool, errBool := Decode(key, stringType, reflect.TypeOf(false))
+	asNil, errNil := Decode(key, stringType, nil)
+
+	// Assert
+	require.NoError(t, errBool)
+	require.NoError(t, errNil)
+	assert.Equal(t, []any{"a", false}, asBool)
+	assert.Equal(t, []any{"a", nil}, asNil)
+}
+
+func TestShouldRejectNonBoolByteWhereBoolExpected(t *testing.T) {
+	// Arrange
+	key := LexKey{'a', 0x00, 0x02}
+
+	// Act
+	_, err := Decode(key, stringType, reflect.TypeOf(false))
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestShouldDecodeHexIntoTypedValues(t *testing.T) {
+	// Arrange: the hex of Encode("user", int64(42), true), as it would appear in a log
+	hexStr := "7573657200800000000000002a0001"
+	schema := []reflect.Type{stringType, int64Type, reflect.TypeOf(false)}
+
+	// Act
+	values, err := DecodeHex(hexStr, schema...)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Encode("user", int64(42), true).ToHexString(), hexStr)
+	assert.Equal(t, []any{"user", int64(42), true}, values)
+}
+
+func TestShouldReportInvalidHexFromDecodeHex(t *testing.T) {
+	// Act
+	_, err := DecodeHex("zz", stringType)
+
+	// Assert
+	require.ErrorIs(t, err, ErrInvalidHex)
+}
+
+func TestShouldReportConsumedBytesFromDecodeOne(t *testing.T) {
+	tests := []struct {
+		name     string
+		buf      []byte
+		typ      reflect.Type
+		value    any
+		consumed int
+	}{
+		{"fixed width with separator", Encode(int64(7), "rest"), int64Type, int64(7), 9},
+		{"fixed width at end", Encode(int64(7)), int64Type, int64(7), 8},
+		{"fixed width followed by payload", append(Encode(true), 0x42), reflect.TypeOf(false), true, 1},
+		{"variable width with separator", Encode("user", int64(7)), stringType, "user", 5},
+		{"variable width at end", Encode("user"), stringType, "user", 4},
+		{"length prefixed", Encode(LengthPrefixed{0, 1}, "x"), lengthPrefixedType, LengthPrefixed{0, 1}, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			value, consumed, err := DecodeOne(tt.buf, tt.typ)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, value)
+			assert.Equal(t, tt.consumed, consumed)
+		})
+	}
+}
+
+func TestShouldChainDecodeOneAcrossParts(t *testing.T) {
+	// Arrange
+	buf := Encode("user", int64(42), true)
+
+	// Act
+	name, n1, err1 := DecodeOne(buf, stringType)
+	id, n2, err2 := DecodeOne(buf[n1:], int64Type)
+	flag, n3, err3 := DecodeOne(buf[n1+n2:], reflect.TypeOf(false))
+
+	// Assert
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+	require.NoError(t, err3)
+	assert.Equal(t, []any{"user", int64(42), true}, []any{name, id, flag})
+	assert.Equal(t, len(buf), n1+n2+n3)
+}
+
+func TestShouldErrorWhenDecodeOneInputIsTooShort(t *testing.T) {
+	// Act
+	_, consumed, err := DecodeOne([]byte{0x80, 0x00}, int64Type)
+
+	// Assert
+	require.Error(t, err)
+	assert.Zero(t, consumed)
+}
+
+func TestShouldDecodeConcatenatedFixedWidthRecords(t *testing.T) {
+	// Arrange
+	id := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	schema := []reflect.Type{int64Type, reflect.TypeOf(uuid.UUID{}), reflect.TypeOf(false)}
+	var buf []byte
+	buf = append(buf, Encode(int64(-1), id, true)...)
+	buf = append(buf, Encode(int64(0), uuid.Nil, false)...)
+	buf = append(buf, Encode(int64(1<<40), id, true)...)
+
+	// Act
+	records, err := DecodeAll(buf, schema)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, [][]any{
+		{int64(-1), id, true},
+		{int64(0), uuid.Nil, false},
+		{int64(1 << 40), id, true},
+	}, records)
+}
+
+func TestShouldRejectInvalidInputInDecodeAll(t *testing.T) {
+	fixed := []reflect.Type{int64Type, reflect.TypeOf(false)}
+	record := Encode(int64(7), true)
+	tests := []struct {
+		name   string
+		input  []byte
+		schema []reflect.Type
+		errMsg string
+	}{
+		{"partial trailing record", append(append([]byte{}, record...), record[:4]...), fixed, "trailing bytes"},
+		{"variable-width part", Encode("a", int64(1)), []reflect.Type{stringType, int64Type}, "not fixed-width"},
+		{"length-prefixed part", Encode(LengthPrefixed("a")), []reflect.Type{lengthPrefixedType}, "not fixed-width"},
+		{"empty schema", record, nil, "no schema"},
+		{"invalid bool byte", append(Encode(int64(7)), Separator, 0x02), fixed, "record 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := DecodeAll(tt.input, tt.schema)
+
+			// Assert
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestShouldDecodeEmptyBufferToNoRecords(t *testing.T) {
+	// Act
+	records, err := DecodeAll(nil, []reflect.Type{int64Type})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestShouldDecodeStringWithOptionalUTF8Check(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []byte
+		checkUTF8 bool
+		expected  string
+		wantErr   bool
+	}{
+		{"valid UTF-8 checked", Encode("héllo, 世界"), true, "héllo, 世界", false},
+		{"empty checked", nil, true, "", false},
+		{"invalid UTF-8 checked", []byte{'a', 0xff, 0xfe}, true, "", true},
+		{"truncated rune checked", []byte("世")[:2], true, "", true},
+		{"invalid UTF-8 unchecked", []byte{'a', 0xff, 0xfe}, false, "a\xff\xfe", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			s, err := DecodeString(tt.input, tt.checkUTF8)
+
+			// Assert
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidUTF8)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, s)
+		})
+	}
+}
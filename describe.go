@@ -0,0 +1,61 @@
+package lexkey
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// describeSeparator joins the annotated parts produced by Describe.
+const describeSeparator = " | "
+
+// Describe renders key as a human-readable breakdown for logs and debugging, e.g.
+// `string("user") | int64(42) | bool(true)`. The schema is interpreted as in Decode.
+//
+// Without a schema, or when the key does not decode with it, Describe falls back to the
+// hex of each Separator-delimited segment, e.g. `75736572 | 800000000000002a`. That
+// segmentation is a guess: fixed-width parts such as integers often contain 0x00 bytes
+// and are split further. The output format is meant for people and may change.
+func Describe(key LexKey, schema ...reflect.Type) string {
+	if len(schema) > 0 {
+		if values, err := Decode(key, schema...); err == nil {
+			parts := make([]string, len(values))
+			for i, v := range values {
+				parts[i] = describeValue(schema[i], v)
+			}
+			return strings.Join(parts, describeSeparator)
+		}
+	}
+	segments := key.Segments()
+	parts := make([]string, len(segments))
+	for i, seg := range segments {
+		parts[i] = hex.EncodeToString(seg)
+	}
+	return strings.Join(parts, describeSeparator)
+}
+
+// describeValue formats a single decoded value as type(value).
+func describeValue(t reflect.Type, v any) string {
+	if t == nil {
+		return "nil"
+	}
+	var s string
+	switch x := v.(type) {
+	case time.Time:
+		s = x.Format(time.RFC3339Nano)
+	case LexKey:
+		s = x.ToHexString()
+	default:
+		switch rv := reflect.ValueOf(v); rv.Kind() {
+		case reflect.String:
+			s = fmt.Sprintf("%q", rv.String())
+		case reflect.Slice:
+			s = hex.EncodeToString(rv.Bytes())
+		default:
+			s = fmt.Sprint(v)
+		}
+	}
+	return fmt.Sprintf("%v(%s)", t, s)
+}
@@ -0,0 +1,76 @@
+package lexkey
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldDescribeKeyWithSchema(t *testing.T) {
+	// Arrange
+	key := Encode("user", 42, true)
+	schema := []reflect.Type{stringType, int64Type, reflect.TypeOf(true)}
+
+	// Act
+	described := Describe(key, schema...)
+
+	// Assert
+	assert.Equal(t, `string("user") | int64(42) | bool(true)`, described)
+}
+
+func TestShouldDescribeEachSupportedTypeReadably(t *testing.T) {
+	id := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	at := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{"bytes", []byte{0xca, 0xfe}, "[]uint8(cafe)"},
+		{"uuid", id, "uuid.UUID(550e8400-e29b-41d4-a716-446655440000)"},
+		{"time", at, "time.Time(2025-01-02T03:04:05.000000006Z)"},
+		{"float", 1.5, "float64(1.5)"},
+		{"named", testStatus(7), "lexkey.testStatus(7)"},
+		{"nil", nil, "nil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			described := Describe(Encode(tt.value), reflect.TypeOf(tt.value))
+
+			// Assert
+			assert.Equal(t, tt.expected, described)
+		})
+	}
+}
+
+func TestShouldFallBackToHexSegmentsWithoutSchema(t *testing.T) {
+	// Arrange
+	key := Encode("user", "alice", true)
+
+	// Act
+	described := Describe(key)
+
+	// Assert
+	assert.Equal(t, "75736572 | 616c696365 | 01", described)
+}
+
+func TestShouldFallBackToHexSegmentsWhenSchemaDoesNotMatch(t *testing.T) {
+	// Arrange
+	key := Encode("user", "alice")
+
+	// Act
+	described := Describe(key, stringType, int64Type)
+
+	// Assert
+	assert.Equal(t, "75736572 | 616c696365", described)
+}
+
+func TestShouldDescribeEmptyKeyAsEmptyString(t *testing.T) {
+	// Act / Assert
+	assert.Empty(t, Describe(LexKey{}))
+}
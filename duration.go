@@ -0,0 +1,25 @@
+package lexkey
+
+import "time"
+
+// EncodeDuration encodes d quantized to unit, e.g. time.Second, so durations in the same bucket
+// share a key: it stores the bucket index floor(d / unit) with the int64 encoding. Flooring
+// (rather than truncating toward zero) keeps buckets the same size on both sides of zero:
+// -500ms falls in bucket -1 with time.Second, not in bucket 0 with +500ms. Keys sort by
+// duration, but only compare keys built with the same unit; they equal Encode(time.Duration)
+// only when unit is time.Nanosecond. A unit <= 0 is treated as time.Nanosecond.
+func EncodeDuration(d, unit time.Duration) LexKey {
+	return Encode(int64(floorBucket(d, unit)))
+}
+
+// floorBucket returns floor(d / unit), treating a unit <= 0 as time.Nanosecond.
+func floorBucket(d, unit time.Duration) time.Duration {
+	if unit <= 0 {
+		unit = time.Nanosecond
+	}
+	bucket := d / unit
+	if d%unit < 0 {
+		bucket--
+	}
+	return bucket
+}
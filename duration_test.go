@@ -0,0 +1,49 @@
+package lexkey
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldShareKeyForDurationsInSameBucket(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b time.Duration
+		unit time.Duration
+	}{
+		{"same second", 1200 * time.Millisecond, 1999 * time.Millisecond, time.Second},
+		{"bucket start", time.Second, 1500 * time.Millisecond, time.Second},
+		{"same minute", 61 * time.Second, 119 * time.Second, time.Minute},
+		{"same negative second", -1 * time.Millisecond, -999 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Assert
+			assert.Equal(t, EncodeDuration(tt.a, tt.unit), EncodeDuration(tt.b, tt.unit))
+		})
+	}
+}
+
+func TestShouldOrderDurationBuckets(t *testing.T) {
+	// Arrange: ascending bucket boundaries around zero
+	durations := []time.Duration{
+		math.MinInt64, -1001 * time.Millisecond, -1 * time.Millisecond, 0,
+		time.Second, math.MaxInt64,
+	}
+
+	// Act / Assert
+	for i := 1; i < len(durations); i++ {
+		prev, next := EncodeDuration(durations[i-1], time.Second), EncodeDuration(durations[i], time.Second)
+		assert.Negative(t, Compare(prev, next), "%v should sort before %v", durations[i-1], durations[i])
+	}
+}
+
+func TestShouldTreatNonPositiveUnitAsNanoseconds(t *testing.T) {
+	// Assert
+	assert.Equal(t, Encode(time.Duration(1500)), EncodeDuration(1500, 0))
+	assert.Equal(t, Encode(time.Duration(-7)), EncodeDuration(-7, -time.Second))
+}
@@ -0,0 +1,291 @@
+package lexkey
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// defaultEncoder backs the package-level encoding functions.
+var defaultEncoder = Encoder{Separator: Separator, EndMarker: EndMarker}
+
+// Encoder encodes LexKeys with configurable separator and end-marker bytes.
+// Use it when a storage backend reserves the default 0x00/0xFF bytes.
+// The package-level functions (NewLexKey, EncodeFirst, EncodeLast, ...) use DefaultEncoder.
+//
+// The configured bytes replace the structural bytes only: the separator between parts,
+// the nil encoding and the range markers. LexKey does not escape data, so values whose
+// encodings contain the configured bytes (e.g. strings, or integers with zero bytes)
+// still contain them; see SPEC.md.
+type Encoder struct {
+	Separator byte      // Separates parts and encodes nil; must sort below EndMarker
+	EndMarker byte      // Marks range upper bounds and encodes struct{}
+	NaN       NaNPolicy // How NaN floats encode; the zero value keeps the legacy canonical pattern
+	TypeTags  bool      // Prefix each part with a type tag byte so "", nil, false and 0 stay distinct
+	Strict    bool      // Reject struct{} and types only encodable through reflection fallbacks
+	MaxKeyLen int       // Reject keys longer than this many bytes with a KeyTooLongError; 0 means unlimited
+}
+
+// DefaultEncoder returns an Encoder using the default Separator (0x00) and EndMarker (0xFF).
+func DefaultEncoder() *Encoder {
+	enc := defaultEncoder
+	return &enc
+}
+
+// NewEncoder creates an Encoder with custom separator and end-marker bytes.
+// Returns an error unless separator < endMarker, which range bounds rely on for ordering.
+func NewEncoder(separator, endMarker byte) (*Encoder, error) {
+	enc := &Encoder{Separator: separator, EndMarker: endMarker}
+	if err := enc.validate(); err != nil {
+		return nil, err
+	}
+	return enc, nil
+}
+
+// validate checks that the configured marker bytes preserve range ordering.
+func (enc *Encoder) validate() error {
+	if enc.Separator >= enc.EndMarker {
+		return fmt.Errorf("invalid encoder: separator 0x%02x must sort below end marker 0x%02x", enc.Separator, enc.EndMarker)
+	}
+	if enc.MaxKeyLen < 0 {
+		return fmt.Errorf("invalid encoder: negative MaxKeyLen %d", enc.MaxKeyLen)
+	}
+	if enc.TypeTags && (enc.Separator >= tagNil || enc.EndMarker <= tagLengthPrefixed) {
+		return fmt.Errorf("invalid encoder: type tags 0x%02x-0x%02x must sort between separator 0x%02x and end marker 0x%02x",
+			tagNil, tagLengthPrefixed, enc.Separator, enc.EndMarker)
+	}
+	return nil
+}
+
+// NewLexKey constructs a LexKey from parts like the package-level NewLexKey,
+// separating parts with the encoder's Separator byte.
+func (enc *Encoder) NewLexKey(parts ...any) (LexKey, error) {
+	return enc.NewLexKeyCap(0, parts...)
+}
+
+// NewLexKeyCap is like NewLexKey but reserves at least capacity bytes of backing storage;
+// see the package-level NewLexKeyCap.
+func (enc *Encoder) NewLexKeyCap(capacity int, parts ...any) (LexKey, error) {
+	if err := enc.validate(); err != nil {
+		return LexKey([]byte{}), err
+	}
+	if len(parts) == 0 {
+		return LexKey([]byte{}), fmt.Errorf("cannot create LexKey: %w", ErrEmptyKey)
+	}
+	canon, err := enc.canonicalizeParts(parts)
+	if err != nil {
+		return LexKey([]byte{}), err
+	}
+	size := enc.estimateSize(canon)
+	if err := enc.checkLen(size); err != nil {
+		return LexKey([]byte{}), err
+	}
+	result := make([]byte, size, max(size, capacity))
+	n, err := enc.encodeParts(result, canon)
+	if err != nil {
+		return LexKey([]byte{}), err
+	}
+	return result[:n], nil
+}
+
+// EncodeSingle encodes exactly one value as a key; see the package-level EncodeSingle.
+func (enc *Encoder) EncodeSingle(v any) (LexKey, error) {
+	if err := enc.validate(); err != nil {
+		return LexKey([]byte{}), err
+	}
+	if parts, ok := v.([]any); ok {
+		return LexKey([]byte{}), fmt.Errorf("EncodeSingle: got %d parts, want exactly one; use NewLexKey for composite keys", len(parts))
+	}
+	if enc.Strict && !strictPart(v) {
+		return LexKey([]byte{}), fmt.Errorf("cannot encode part (%T): %w", v, &UnsupportedTypeError{Type: reflect.TypeOf(v)})
+	}
+	canon := [1]any{canonicalizePart(v)}
+	size := enc.estimateSize(canon[:])
+	if err := enc.checkLen(size); err != nil {
+		return LexKey([]byte{}), err
+	}
+	result := make(LexKey, size)
+	n, err := enc.encodePart(result, canon[0])
+	if err != nil {
+		return LexKey([]byte{}), fmt.Errorf("cannot encode part (%T): %w", v, err)
+	}
+	return result[:n], nil
+}
+
+// Encode constructs a LexKey from pre-validated parts, panicking if encoding fails.
+func (enc *Encoder) Encode(parts ...any) LexKey {
+	key, err := enc.NewLexKey(parts...)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode key with pre-validated parts: %v", err))
+	}
+	return key
+}
+
+// EncodeInto writes the encoding of parts into dst and returns the number of bytes written.
+// The dst slice must have length >= EncodeSize(parts...).
+func (enc *Encoder) EncodeInto(dst []byte, parts ...any) (int, error) {
+	if err := enc.validate(); err != nil {
+		return 0, err
+	}
+	if len(parts) == 0 {
+		return 0, nil
+	}
+	canon, err := enc.canonicalizeParts(parts)
+	if err != nil {
+		return 0, err
+	}
+	need := enc.estimateSize(canon)
+	if err := enc.checkLen(need); err != nil {
+		return 0, err
+	}
+	if len(dst) < need {
+		return 0, fmt.Errorf("EncodeInto: dst too small: need %d bytes, have %d", need, len(dst))
+	}
+	return enc.encodeParts(dst, canon)
+}
+
+// EncodedSize returns the exact length of the key NewLexKey would build from parts, including
+// separators and type tags, without building it; see the package-level EncodedSize.
+func (enc *Encoder) EncodedSize(parts ...any) (int, error) {
+	if err := enc.validate(); err != nil {
+		return 0, err
+	}
+	if len(parts) == 0 {
+		return 0, fmt.Errorf("cannot size LexKey: %w", ErrEmptyKey)
+	}
+	canon, err := enc.canonicalizeParts(parts)
+	if err != nil {
+		return 0, err
+	}
+	for i, part := range canon {
+		if _, ok := partSize(part); !ok {
+			return 0, fmt.Errorf("cannot size part %d (%T): %w", i, parts[i], &UnsupportedTypeError{Type: reflect.TypeOf(part)})
+		}
+	}
+	size := enc.estimateSize(canon)
+	if err := enc.checkLen(size); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// EncodeFirst returns the prefix built from parts followed by the encoder's Separator,
+// sorting before any extension of the prefix. Panics on encoding errors.
+func (enc *Encoder) EncodeFirst(parts ...any) LexKey {
+	prefix := enc.Encode(parts...)
+	return append(prefix, enc.Separator)
+}
+
+// EncodeLast returns the prefix built from parts followed by the encoder's EndMarker,
+// sorting after any extension of the prefix. Panics on encoding errors.
+func (enc *Encoder) EncodeLast(parts ...any) LexKey {
+	prefix := enc.Encode(parts...)
+	return append(prefix, enc.EndMarker)
+}
+
+// encodeParts writes already-canonicalized parts into dst separated by the encoder's Separator.
+// dst must be sized with enc.estimateSize.
+func (enc *Encoder) encodeParts(dst []byte, canon []any) (int, error) {
+	pos := 0
+	for i, part := range canon {
+		n, err := enc.encodePart(dst[pos:], part)
+		if err != nil {
+			return 0, fmt.Errorf("cannot encode part %d (%T): %w", i, part, err)
+		}
+		pos += n
+		if i < len(canon)-1 {
+			dst[pos] = enc.Separator
+			pos++
+		}
+	}
+	return pos, nil
+}
+
+// encodePart writes a single part, preceded by its type tag when TypeTags is set.
+func (enc *Encoder) encodePart(dst []byte, v any) (int, error) {
+	if !enc.TypeTags {
+		return enc.encodeValue(dst, v)
+	}
+	tag, ok := typeTag(v)
+	if !ok {
+		return enc.encodeValue(dst, v)
+	}
+	dst[0] = tag
+	if v == nil {
+		return 1, nil
+	}
+	n, err := enc.encodeValue(dst[1:], v)
+	if err != nil {
+		return 0, err
+	}
+	return n + 1, nil
+}
+
+// estimateSize returns the encoded size of canonicalized parts, including type tags.
+func (enc *Encoder) estimateSize(canon []any) int {
+	size := estimateSize(canon)
+	if enc.TypeTags {
+		for _, part := range canon {
+			switch part.(type) {
+			case nil, struct{}:
+				// nil is the tag alone, replacing its Separator byte; struct{} is untagged
+			default:
+				size++
+			}
+		}
+	}
+	return size
+}
+
+// canonicalizeParts canonicalizes parts for encoding, first rejecting parts Strict disallows.
+func (enc *Encoder) canonicalizeParts(parts []any) ([]any, error) {
+	if err := enc.checkStrict(parts); err != nil {
+		return nil, err
+	}
+	canon := make([]any, len(parts))
+	for i, p := range parts {
+		canon[i] = canonicalizePart(p)
+	}
+	return canon, nil
+}
+
+// checkLen returns a KeyTooLongError if a key of size bytes exceeds MaxKeyLen.
+func (enc *Encoder) checkLen(size int) error {
+	if enc.MaxKeyLen > 0 && size > enc.MaxKeyLen {
+		return &KeyTooLongError{Len: size, Max: enc.MaxKeyLen}
+	}
+	return nil
+}
+
+// checkStrict returns an error for the first part Strict disallows, if Strict is set.
+func (enc *Encoder) checkStrict(parts []any) error {
+	if !enc.Strict {
+		return nil
+	}
+	for i, p := range parts {
+		if !strictPart(p) {
+			return fmt.Errorf("cannot encode part %d (%T): %w", i, p, &UnsupportedTypeError{Type: reflect.TypeOf(p)})
+		}
+	}
+	return nil
+}
+
+// strictPart reports whether v has an explicit encoding, as required by Strict mode.
+// Strict mode rejects the struct{} end sentinel and everything canonicalizePart handles
+// by reflection: pointers, named types encoded by kind (e.g. time.Month), and byte arrays.
+func strictPart(v any) bool {
+	switch v.(type) {
+	case nil, string, []byte, LexKey, uuid.UUID, bool,
+		int, int8, int16, int32, int64, uint8, uint16, uint32, uint64, float32, float64,
+		time.Time, time.Duration, json.Number, JSONNumberAsInt, JSONNumberAsFloat,
+		Float16, Decimal, ZonedTime, LengthPrefixed, CaseFold, *big.Rat, big.Rat, NonNeg, netip.Addr, TriBool:
+		return true
+	}
+	_, ok := sqlNullValue(v)
+	return ok
+}
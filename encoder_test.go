@@ -0,0 +1,259 @@
+package lexkey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldMatchPackageFunctionsWithDefaultEncoder(t *testing.T) {
+	// Arrange
+	enc := DefaultEncoder()
+	parts := []any{"tenant", 42, true, nil}
+
+	// Act
+	key, err := enc.NewLexKey(parts...)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Encode(parts...), key)
+	assert.Equal(t, EncodeFirst(parts...), enc.EncodeFirst(parts...))
+	assert.Equal(t, EncodeLast(parts...), enc.EncodeLast(parts...))
+}
+
+func TestShouldUseCustomMarkerBytes(t *testing.T) {
+	// Arrange
+	enc, err := NewEncoder(0x01, 0xFE)
+	require.NoError(t, err)
+
+	// Act
+	key := enc.Encode("a", "b", nil, struct{}{})
+
+	// Assert
+	test.AssertHexEqual(t, "610162010101fe", key)
+	test.AssertHexEqual(t, "6101", enc.EncodeFirst("a"))
+	test.AssertHexEqual(t, "61fe", enc.EncodeLast("a"))
+}
+
+func TestShouldPreserveOrderingWithCustomMarkerBytes(t *testing.T) {
+	// Arrange
+	enc, err := NewEncoder(0x01, 0xFE)
+	require.NoError(t, err)
+	first := enc.EncodeFirst("tenant", "users")
+	last := enc.EncodeLast("tenant", "users")
+	inside := []LexKey{
+		enc.Encode("tenant", "users", "alice"),
+		enc.Encode("tenant", "users", "bob"),
+		enc.Encode("tenant", "users", 42),
+	}
+	outside := []LexKey{
+		enc.Encode("tenant", "user"),
+		enc.Encode("tenant", "usera"),
+		enc.Encode("tenantz"),
+	}
+
+	// Act / Assert
+	for _, k := range inside {
+		assert.Less(t, Compare(first, k), 0, "first should precede %x", k)
+		assert.Less(t, Compare(k, last), 0, "last should follow %x", k)
+	}
+	for _, k := range outside {
+		inRange := Compare(first, k) <= 0 && Compare(k, last) < 0
+		assert.False(t, inRange, "%x should be outside the prefix range", k)
+	}
+}
+
+func TestShouldEncodeIntoWithCustomMarkerBytes(t *testing.T) {
+	// Arrange
+	enc, err := NewEncoder(0x01, 0xFE)
+	require.NoError(t, err)
+	dst := make([]byte, EncodeSize("a", "b"))
+
+	// Act
+	n, err := enc.EncodeInto(dst, "a", "b")
+
+	// Assert
+	require.NoError(t, err)
+	test.AssertHexEqual(t, "610162", dst[:n])
+}
+
+func TestShouldRejectInvalidMarkerBytes(t *testing.T) {
+	tests := []struct {
+		name      string
+		separator byte
+		endMarker byte
+	}{
+		{"equal", 0x01, 0x01},
+		{"inverted", 0xFE, 0x01},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			enc, err := NewEncoder(tt.separator, tt.endMarker)
+
+			// Assert
+			require.Error(t, err)
+			assert.Nil(t, enc)
+		})
+	}
+}
+
+func TestShouldErrorWhenZeroValueEncoderIsUsed(t *testing.T) {
+	// Arrange
+	var enc Encoder
+
+	// Act
+	_, err := enc.NewLexKey("a")
+	_, intoErr := enc.EncodeInto(make([]byte, 8), "a")
+
+	// Assert
+	require.Error(t, err)
+	require.Error(t, intoErr)
+}
+
+func TestShouldErrorWhenEncoderReceivesNoParts(t *testing.T) {
+	// Act
+	_, err := DefaultEncoder().NewLexKey()
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestShouldRejectImplicitEncodingsInStrictMode(t *testing.T) {
+	type status int
+	var id [4]byte
+	name := "a"
+	tests := []struct {
+		name string
+		part any
+	}{
+		{"end sentinel", struct{}{}},
+		{"named integer", status(1)},
+		{"calendar enum", time.March},
+		{"pointer", &name},
+		{"byte array", id},
+		{"unsupported", map[string]int{}},
+	}
+	enc := DefaultEncoder()
+	enc.Strict = true
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := enc.NewLexKey("tenant", tt.part)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrUnsupportedType)
+		})
+	}
+}
+
+func TestShouldAcceptExplicitTypesInStrictMode(t *testing.T) {
+	// Arrange
+	enc := DefaultEncoder()
+	enc.Strict = true
+	parts := []any{"a", []byte{1}, nil, true, 1, int8(2), uint16(3), 1.5, float32(2.5), time.Unix(0, 0), time.Second, Decimal{Coefficient: 1}}
+
+	// Act
+	key, err := enc.NewLexKey(parts...)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Encode(parts...), key)
+}
+
+func TestShouldKeepEndSentinelInDefaultMode(t *testing.T) {
+	// Act
+	key, err := DefaultEncoder().NewLexKey("a", struct{}{})
+
+	// Assert
+	require.NoError(t, err)
+	test.AssertHexEqual(t, "6100ff", key)
+}
+
+func TestShouldApplyStrictModeToEncodeIntoAndBatch(t *testing.T) {
+	// Arrange
+	enc := DefaultEncoder()
+	enc.Strict = true
+
+	// Act
+	_, errInto := enc.EncodeInto(make([]byte, 16), struct{}{})
+	_, errBatch := enc.EncodeBatch([][]any{{"a"}, {struct{}{}}})
+
+	// Assert
+	assert.ErrorIs(t, errInto, ErrUnsupportedType)
+	assert.ErrorIs(t, errBatch, ErrUnsupportedType)
+}
+
+func TestShouldEnforceMaxKeyLen(t *testing.T) {
+	// Arrange: Encode("abc", int64(1)) is 3 + 1 + 8 = 12 bytes
+	tests := []struct {
+		name    string
+		max     int
+		wantErr bool
+	}{
+		{"unlimited", 0, false},
+		{"under the limit", 13, false},
+		{"exactly at the limit", 12, false},
+		{"over the limit", 11, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := DefaultEncoder()
+			enc.MaxKeyLen = tt.max
+
+			// Act
+			key, err := enc.NewLexKey("abc", int64(1))
+
+			// Assert
+			if !tt.wantErr {
+				require.NoError(t, err)
+				assert.Len(t, key, 12)
+				return
+			}
+			require.ErrorIs(t, err, ErrKeyTooLong)
+			var tooLong *KeyTooLongError
+			require.ErrorAs(t, err, &tooLong)
+			assert.Equal(t, 12, tooLong.Len)
+			assert.Equal(t, 11, tooLong.Max)
+			assert.Contains(t, err.Error(), "12 bytes exceeds maximum 11")
+		})
+	}
+}
+
+func TestShouldApplyMaxKeyLenToAllEncoderPaths(t *testing.T) {
+	// Arrange
+	enc := DefaultEncoder()
+	enc.MaxKeyLen = 4
+
+	// Act
+	_, errSingle := enc.EncodeSingle("hello")
+	_, errInto := enc.EncodeInto(make([]byte, 16), "hello")
+	_, errSize := enc.EncodedSize("hello")
+	_, errBatch := enc.EncodeBatch([][]any{{"ok"}, {"hello"}})
+
+	// Assert
+	require.ErrorIs(t, errSingle, ErrKeyTooLong)
+	require.ErrorIs(t, errInto, ErrKeyTooLong)
+	require.ErrorIs(t, errSize, ErrKeyTooLong)
+	require.ErrorIs(t, errBatch, ErrKeyTooLong)
+	assert.Contains(t, errBatch.Error(), "row 1")
+}
+
+func TestShouldRejectNegativeMaxKeyLen(t *testing.T) {
+	// Arrange
+	enc := DefaultEncoder()
+	enc.MaxKeyLen = -1
+
+	// Act
+	_, err := enc.NewLexKey("a")
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "negative MaxKeyLen")
+}
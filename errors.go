@@ -0,0 +1,63 @@
+package lexkey
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrEmptyKey is returned when a key is requested from no parts.
+	ErrEmptyKey = errors.New("no parts provided")
+	// ErrUnsupportedType is matched by every UnsupportedTypeError, so callers can test
+	// errors.Is(err, ErrUnsupportedType) without inspecting the offending type.
+	ErrUnsupportedType = errors.New("unsupported type")
+	// ErrInvalidHex is returned when a hex (or JSON/text hex) representation cannot be decoded.
+	ErrInvalidHex = errors.New("invalid hex string")
+	// ErrInvalidBase64 is returned when a Base64Key JSON/text representation cannot be decoded.
+	ErrInvalidBase64 = errors.New("invalid base64 string")
+	// ErrInvalidUTF8 is returned by DecodeString when UTF-8 checking is requested and fails.
+	ErrInvalidUTF8 = errors.New("invalid UTF-8")
+	// ErrKeyTooLong is matched by every KeyTooLongError.
+	ErrKeyTooLong = errors.New("key too long")
+	// ErrFrameTooLong is returned when a stream frame is longer than the Decoder's MaxFrameLen.
+	ErrFrameTooLong = errors.New("frame too long")
+	// ErrLengthOverflow is returned when a length does not fit a 4-byte length prefix, in
+	// EncodeFixedSchema, LengthPrefixed parts, EncodeLengthPrefixed or a StreamEncoder frame.
+	ErrLengthOverflow = errors.New("length exceeds uint32 prefix")
+	// ErrCorruptEnvelope is returned when a versioned envelope is truncated or fails its checksum.
+	ErrCorruptEnvelope = errors.New("corrupt envelope")
+	// ErrUnsupportedVersion is returned when a versioned envelope has an unknown format version.
+	ErrUnsupportedVersion = errors.New("unsupported envelope version")
+)
+
+// UnsupportedTypeError reports a part whose type cannot be encoded or decoded.
+// Use errors.As to retrieve the offending type.
+type UnsupportedTypeError struct {
+	Type reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("unsupported type %v", e.Type)
+}
+
+// Is reports whether target is ErrUnsupportedType.
+func (e *UnsupportedTypeError) Is(target error) bool {
+	return target == ErrUnsupportedType
+}
+
+// KeyTooLongError reports a key longer than an Encoder's MaxKeyLen.
+// Use errors.As to retrieve the lengths.
+type KeyTooLongError struct {
+	Len int // Encoded length of the rejected key
+	Max int // The encoder's MaxKeyLen
+}
+
+func (e *KeyTooLongError) Error() string {
+	return fmt.Sprintf("key too long: %d bytes exceeds maximum %d", e.Len, e.Max)
+}
+
+// Is reports whether target is ErrKeyTooLong.
+func (e *KeyTooLongError) Is(target error) bool {
+	return target == ErrKeyTooLong
+}
@@ -0,0 +1,68 @@
+package lexkey
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldMatchSentinelErrorsWithErrorsIs(t *testing.T) {
+	tests := []struct {
+		name     string
+		act      func() error
+		expected error
+	}{
+		{"NewLexKey without parts", func() error { _, err := NewLexKey(); return err }, ErrEmptyKey},
+		{"Encoder without parts", func() error { _, err := DefaultEncoder().NewLexKey(); return err }, ErrEmptyKey},
+		{"EncodeFixedSchema without values", func() error { _, err := EncodeFixedSchema(nil); return err }, ErrEmptyKey},
+		{"unsupported part", func() error { _, err := NewLexKey("a", map[string]int{}); return err }, ErrUnsupportedType},
+		{"unsupported appended part", func() error { _, err := Encode("a").Append(struct{ x int }{}); return err }, ErrUnsupportedType},
+		{"unsupported decode type", func() error { _, err := Decode(Encode("a"), reflect.TypeOf(map[string]int{})); return err }, ErrUnsupportedType},
+		{"irreversible decode type", func() error { _, err := Decode(Encode(1), reflect.TypeOf(json.Number(""))); return err }, ErrUnsupportedType},
+		{"invalid hex character", func() error { var k LexKey; return k.FromHexString("zz") }, ErrInvalidHex},
+		{"odd length hex", func() error { var k LexKey; return k.FromHexString("abc") }, ErrInvalidHex},
+		{"invalid hex text", func() error { var k LexKey; return k.UnmarshalText([]byte("zz")) }, ErrInvalidHex},
+		{"invalid hex JSON", func() error { var k LexKey; return json.Unmarshal([]byte(`"zz"`), &k) }, ErrInvalidHex},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.act()
+
+			// Assert
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestShouldCarryOffendingTypeInUnsupportedTypeError(t *testing.T) {
+	// Arrange
+	part := map[string]int{}
+
+	// Act
+	_, err := NewLexKey("a", part)
+
+	// Assert
+	var typeErr *UnsupportedTypeError
+	require.True(t, errors.As(err, &typeErr))
+	assert.Equal(t, reflect.TypeOf(part), typeErr.Type)
+	assert.NotErrorIs(t, err, ErrEmptyKey)
+}
+
+func TestShouldKeepUnderlyingHexError(t *testing.T) {
+	// Arrange
+	var k LexKey
+
+	// Act
+	err := k.FromHexString("abc")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidHex)
+	assert.Contains(t, err.Error(), "odd length")
+}
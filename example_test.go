@@ -0,0 +1,55 @@
+package lexkey_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/fgrzl/lexkey"
+)
+
+// Compare plugs into any three-way comparator API, such as the slices package.
+func ExampleCompare() {
+	keys := []lexkey.LexKey{
+		lexkey.Encode("user", 30),
+		lexkey.Encode("user", 2),
+		lexkey.Encode("user", 100),
+	}
+
+	slices.SortFunc(keys, lexkey.Compare)
+
+	for _, k := range keys {
+		fmt.Println(k.ToHexString())
+	}
+	// Output:
+	// 75736572008000000000000002
+	// 7573657200800000000000001e
+	// 75736572008000000000000064
+}
+
+// Less plugs into ordered containers that take a less function, e.g. a B-tree from
+// github.com/google/btree:
+//
+//	tree := btree.NewG[lexkey.LexKey](32, lexkey.Less)
+//	tree.ReplaceOrInsert(lexkey.Encode("user", 42))
+//	tree.Ascend(func(k lexkey.LexKey) bool { ...; return true })
+//
+// The sorted slice below behaves the same way without an extra dependency.
+func ExampleLess() {
+	var index []lexkey.LexKey
+	insert := func(k lexkey.LexKey) {
+		i, _ := slices.BinarySearchFunc(index, k, lexkey.Compare)
+		index = slices.Insert(index, i, k)
+	}
+
+	insert(lexkey.Encode("b"))
+	insert(lexkey.Encode("c"))
+	insert(lexkey.Encode("a"))
+
+	for _, k := range index {
+		fmt.Println(string(k), lexkey.Less(k, lexkey.Encode("b")))
+	}
+	// Output:
+	// a true
+	// b false
+	// c false
+}
@@ -0,0 +1,87 @@
+package lexkey
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// lengthPrefixSize is the width of the big-endian uint32 length written before
+// variable-length fields in length-prefixed encodings.
+const lengthPrefixSize = 4
+
+// checkLengthPrefix returns an error wrapping ErrLengthOverflow if n does not fit in a uint32
+// length prefix. Every writer of a uint32 length prefix checks through it.
+func checkLengthPrefix(n int) error {
+	if uint64(n) > math.MaxUint32 {
+		return fmt.Errorf("%w: %d bytes", ErrLengthOverflow, n)
+	}
+	return nil
+}
+
+// EncodeFixedSchema encodes values against a known schema using length prefixes instead of separators.
+// Each variable-length field (string, []byte, LexKey) is written as a 4-byte big-endian length
+// followed by its bytes; fixed-width fields are written exactly as NewLexKey would encode them.
+// No separators are emitted, so field boundaries are unambiguous: ("ab", "c") and ("a", "bc")
+// can never collide.
+//
+// Ordering: variable-length fields sort by length first, then by content. This differs from
+// NewLexKey, where strings sort purely byte-wise; only compare keys produced by the same schema.
+//
+// The schema must have one entry per value; a value must have exactly the declared type
+// (a nil schema entry matches a nil value).
+func EncodeFixedSchema(schema []reflect.Type, values ...any) (LexKey, error) {
+	if len(values) == 0 {
+		return LexKey([]byte{}), fmt.Errorf("cannot create LexKey: %w", ErrEmptyKey)
+	}
+	if len(schema) != len(values) {
+		return LexKey([]byte{}), fmt.Errorf("schema has %d fields but %d values were provided", len(schema), len(values))
+	}
+	canon := make([]any, len(values))
+	size := 0
+	for i, v := range values {
+		if reflect.TypeOf(v) != schema[i] {
+			return LexKey([]byte{}), fmt.Errorf("field %d: expected %v, got %T", i, schema[i], v)
+		}
+		c := canonicalizePart(v)
+		canon[i] = c
+		n, variable := variableLength(c)
+		if variable {
+			if err := checkLengthPrefix(n); err != nil {
+				return LexKey([]byte{}), fmt.Errorf("field %d: %w", i, err)
+			}
+			size += lengthPrefixSize + n
+		} else {
+			size += estimateSize([]any{c})
+		}
+	}
+	result := make([]byte, size)
+	pos := 0
+	for i, c := range canon {
+		if n, variable := variableLength(c); variable {
+			binary.BigEndian.PutUint32(result[pos:], uint32(n))
+			pos += lengthPrefixSize
+		}
+		n, err := encodeInto(result[pos:], c)
+		if err != nil {
+			return LexKey([]byte{}), fmt.Errorf("cannot encode field %d (%T): %w", i, values[i], err)
+		}
+		pos += n
+	}
+	return result[:pos], nil
+}
+
+// variableLength reports the byte length of v and whether v is a variable-length type.
+func variableLength(v any) (int, bool) {
+	switch x := v.(type) {
+	case string:
+		return len(x), true
+	case []byte:
+		return len(x), true
+	case LexKey:
+		return len(x), true
+	default:
+		return 0, false
+	}
+}
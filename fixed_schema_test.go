@@ -0,0 +1,78 @@
+package lexkey
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	stringType = reflect.TypeOf("")
+	int64Type  = reflect.TypeOf(int64(0))
+)
+
+func TestShouldNotCollideWhenFieldBoundariesShift(t *testing.T) {
+	// Arrange
+	schema := []reflect.Type{stringType, stringType}
+
+	// Act
+	a, errA := EncodeFixedSchema(schema, "ab", "c")
+	b, errB := EncodeFixedSchema(schema, "a", "bc")
+
+	// Assert
+	require.NoError(t, errA)
+	require.NoError(t, errB)
+	assert.NotEqual(t, a, b)
+	test.AssertHexEqual(t, "0000000261620000000163", a)
+	test.AssertHexEqual(t, "0000000161000000026263", b)
+}
+
+func TestShouldEncodeFixedWidthFieldsWithoutPrefix(t *testing.T) {
+	// Arrange
+	schema := []reflect.Type{stringType, int64Type}
+
+	// Act
+	key, err := EncodeFixedSchema(schema, "user", int64(42))
+
+	// Assert
+	require.NoError(t, err)
+	test.AssertHexEqual(t, "0000000475736572800000000000002a", key)
+}
+
+func TestShouldOrderFixedSchemaKeysByLengthThenContent(t *testing.T) {
+	// Arrange
+	schema := []reflect.Type{stringType}
+	short, _ := EncodeFixedSchema(schema, "b")
+	long, _ := EncodeFixedSchema(schema, "aa")
+	longer, _ := EncodeFixedSchema(schema, "ab")
+
+	// Act / Assert
+	assert.Less(t, Compare(short, long), 0)
+	assert.Less(t, Compare(long, longer), 0)
+}
+
+func TestShouldErrorWhenFixedSchemaDoesNotMatchValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema []reflect.Type
+		values []any
+	}{
+		{"no values", []reflect.Type{stringType}, nil},
+		{"count mismatch", []reflect.Type{stringType}, []any{"a", "b"}},
+		{"type mismatch", []reflect.Type{int64Type}, []any{"a"}},
+		{"unsupported type", []reflect.Type{reflect.TypeOf(map[int]int{})}, []any{map[int]int{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := EncodeFixedSchema(tt.schema, tt.values...)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package lexkey
+
+// EncodeFlags packs bools into bits, most significant bit first, so the first flag is the
+// most significant: EncodeFlags(true, false) sorts after EncodeFlags(false, true).
+// Up to 8 flags fit in one byte; more continue into further bytes, and unused low bits are
+// zero. Only compare keys built from the same number of flags. An all-false byte is 0x00,
+// which also serves as the Separator, so place flags last or at a fixed position when
+// splitting keys.
+func EncodeFlags(bits ...bool) LexKey {
+	out := make(LexKey, (len(bits)+7)/8)
+	for i, b := range bits {
+		if b {
+			out[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	return out
+}
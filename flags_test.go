@@ -0,0 +1,53 @@
+package lexkey
+
+import (
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldPackFlagsMostSignificantFirst(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    []bool
+		expected string
+	}{
+		{"no flags", nil, ""},
+		{"first only", []bool{true}, "80"},
+		{"two flags", []bool{true, true}, "c0"},
+		{"eight flags", []bool{false, true, false, true, false, true, false, true}, "55"},
+		{"ninth flag spills over", []bool{true, false, false, false, false, false, false, false, true}, "8080"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act / Assert
+			test.AssertHexEqual(t, tt.expected, EncodeFlags(tt.flags...))
+		})
+	}
+}
+
+func TestShouldSortFlagsByFirstFlagSignificance(t *testing.T) {
+	// Act / Assert
+	assert.Positive(t, Compare(EncodeFlags(true, false), EncodeFlags(false, true)))
+	assert.Positive(t, Compare(EncodeFlags(false, true, false), EncodeFlags(false, false, true)))
+}
+
+func TestShouldOrderAllFlagCombinationsAsBinaryNumbers(t *testing.T) {
+	// Arrange
+	const n = 3
+	keys := make([]LexKey, 1<<n)
+	for v := range keys {
+		flags := make([]bool, n)
+		for i := range flags {
+			flags[i] = v&(1<<(n-1-i)) != 0
+		}
+		keys[v] = EncodeFlags(flags...)
+	}
+
+	// Act / Assert
+	for v := 1; v < len(keys); v++ {
+		assert.Negative(t, Compare(keys[v-1], keys[v]), "combination %03b should sort before %03b", v-1, v)
+	}
+}
@@ -0,0 +1,119 @@
+package lexkey
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Float16 is an IEEE 754 half-precision float stored as its raw bits.
+// Unlike float32, it is not widened to float64: it encodes as 2 bytes using the same
+// sign-bit transform as the other float encodings, so keys stay compact.
+type Float16 uint16
+
+const (
+	float16SignBit      = 0x8000
+	float16CanonicalNaN = 0x7E01
+)
+
+// Float16FromFloat32 converts f to the nearest half-precision value (round half to even).
+// Values beyond the half-precision range become ±Inf; NaN stays NaN.
+func Float16FromFloat32(f float32) Float16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & float16SignBit
+	exp := int((bits >> 23) & 0xFF)
+	mant := bits & 0x7FFFFF
+
+	if exp == 0xFF {
+		if mant != 0 {
+			return Float16(sign | 0x7E00)
+		}
+		return Float16(sign | 0x7C00)
+	}
+	e := exp - 127 + 15
+	if e >= 0x1F {
+		return Float16(sign | 0x7C00)
+	}
+	if e <= 0 {
+		// Subnormal half (or underflow to zero).
+		if e < -10 {
+			return Float16(sign)
+		}
+		mant |= 0x800000
+		shift := uint(14 - e)
+		half := mant >> shift
+		rem := mant & (1<<shift - 1)
+		halfway := uint32(1) << (shift - 1)
+		if rem > halfway || (rem == halfway && half&1 == 1) {
+			half++
+		}
+		return Float16(sign | uint16(half))
+	}
+	half := uint16(e)<<10 | uint16(mant>>13)
+	rem := mant & 0x1FFF
+	if rem > 0x1000 || (rem == 0x1000 && half&1 == 1) {
+		half++ // a carry into the exponent rounds up correctly, possibly to Inf
+	}
+	return Float16(sign | half)
+}
+
+// Float32 returns the exact float32 value of h.
+func (h Float16) Float32() float32 {
+	sign := uint32(h&float16SignBit) << 16
+	exp := uint32(h>>10) & 0x1F
+	mant := uint32(h & 0x3FF)
+	switch exp {
+	case 0:
+		// Zero or subnormal: mant * 2^-24 is exact in float32.
+		f := float32(mant) / (1 << 24)
+		if sign != 0 {
+			f = -f
+		}
+		return f
+	case 0x1F:
+		return math.Float32frombits(sign | 0x7F800000 | mant<<13)
+	default:
+		return math.Float32frombits(sign | (exp+112)<<23 | mant<<13)
+	}
+}
+
+// IsNaN reports whether h is a NaN.
+func (h Float16) IsNaN() bool {
+	return h&0x7C00 == 0x7C00 && h&0x3FF != 0
+}
+
+// encodeFloat16 encodes a Float16 into 2 bytes, ensuring lexicographic ordering.
+// Flips the sign bit for positive numbers and all bits for negative numbers.
+// NaN is encoded as a canonical value (0x7E01); -0.0 encodes like +0.0.
+func encodeFloat16(v Float16) []byte {
+	buf := make([]byte, 2)
+	binary.BigEndian.PutUint16(buf, float16OrderedBits(v))
+	return buf
+}
+
+// float16OrderedBits applies the order-preserving float transform to half-precision bits.
+func float16OrderedBits(v Float16) uint16 {
+	if v.IsNaN() {
+		return float16CanonicalNaN
+	}
+	bits := uint16(v)
+	if bits == float16SignBit {
+		bits = 0 // Normalize -0.0 to +0.0
+	}
+	if bits&float16SignBit != 0 {
+		return ^bits // Flip all bits for negative numbers
+	}
+	return bits ^ float16SignBit // Flip sign bit for positive numbers
+}
+
+// decodeFloat16Bits reverses float16OrderedBits. Every NaN pattern produced by a NaNPolicy
+// decodes as the quiet NaN 0x7E00.
+func decodeFloat16Bits(bits uint16) Float16 {
+	switch bits {
+	case float16CanonicalNaN, 0xFE00, 0x01FF:
+		return Float16(0x7E00)
+	}
+	if bits&float16SignBit != 0 {
+		return Float16(bits ^ float16SignBit)
+	}
+	return Float16(^bits)
+}
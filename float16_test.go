@@ -0,0 +1,117 @@
+package lexkey
+
+import (
+	"encoding/hex"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldEncodeFloat16AsTwoOrderedBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Float16
+		expected string
+	}{
+		{"one", Float16(0x3C00), "bc00"},
+		{"negative two", Float16(0xC000), "3fff"},
+		{"zero", Float16(0x0000), "8000"},
+		{"positive infinity", Float16(0x7C00), "fc00"},
+		{"negative infinity", Float16(0xFC00), "03ff"},
+		{"NaN", Float16(0x7E00), "7e01"},
+		{"negative NaN", Float16(0xFE12), "7e01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			key := Encode(tt.input)
+
+			// Assert
+			test.AssertHexEqual(t, tt.expected, key)
+			assert.Equal(t, tt.expected, hex.EncodeToString(encodeFloat16(tt.input)))
+		})
+	}
+}
+
+func TestShouldSortFloat16ValuesInNumericOrder(t *testing.T) {
+	// Arrange
+	values := []Float16{
+		0xFC00, // -Inf
+		0xFBFF, // -65504
+		0xBC00, // -1
+		0x8001, // -smallest subnormal
+		0x0000, // 0
+		0x0001, // smallest subnormal
+		0x3C00, // 1
+		0x7BFF, // 65504
+		0x7C00, // +Inf
+	}
+
+	// Act / Assert
+	for i := 0; i < len(values)-1; i++ {
+		a, b := Encode(values[i]), Encode(values[i+1])
+		assert.Less(t, Compare(a, b), 0, "%v should sort before %v", values[i].Float32(), values[i+1].Float32())
+	}
+}
+
+func TestShouldSortAllFiniteFloat16ValuesInNumericOrder(t *testing.T) {
+	// Arrange: every non-NaN half value except negative zero
+	var values []Float16
+	for i := 0; i <= math.MaxUint16; i++ {
+		h := Float16(i)
+		if h.IsNaN() || h == float16SignBit {
+			continue
+		}
+		values = append(values, h)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Float32() < values[j].Float32() })
+
+	// Act / Assert
+	for i := 0; i < len(values)-1; i++ {
+		a, b := encodeFloat16(values[i]), encodeFloat16(values[i+1])
+		require.Less(t, Compare(a, b), 0, "%v should sort before %v", values[i].Float32(), values[i+1].Float32())
+	}
+}
+
+func TestShouldConvertFloat32ToFloat16(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    float32
+		expected Float16
+	}{
+		{"one", 1, 0x3C00},
+		{"negative two", -2, 0xC000},
+		{"max half", 65504, 0x7BFF},
+		{"overflow", 1e6, 0x7C00},
+		{"negative overflow", -1e6, 0xFC00},
+		{"smallest subnormal", float32(math.Ldexp(1, -24)), 0x0001},
+		{"underflow", float32(math.Ldexp(1, -30)), 0x0000},
+		{"halfway rounds to even", 1 + float32(math.Ldexp(1, -11)), 0x3C00},
+		{"above halfway rounds up", 1 + 3*float32(math.Ldexp(1, -11)), 0x3C02},
+		{"infinity", float32(math.Inf(1)), 0x7C00},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act / Assert
+			assert.Equal(t, tt.expected, Float16FromFloat32(tt.input))
+		})
+	}
+	assert.True(t, Float16FromFloat32(float32(math.NaN())).IsNaN())
+}
+
+func TestShouldRoundTripFloat16ThroughFloat32(t *testing.T) {
+	for i := 0; i <= math.MaxUint16; i++ {
+		h := Float16(i)
+		if h.IsNaN() {
+			continue
+		}
+		// Act / Assert
+		require.Equal(t, h, Float16FromFloat32(h.Float32()), "bits %04x", i)
+	}
+}
@@ -0,0 +1,120 @@
+package lexkey
+
+import (
+	"math"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fuzzSchema describes the key shape built by FuzzEncodeDecode; the string is last so it may contain 0x00.
+var fuzzSchema = []reflect.Type{
+	reflect.TypeOf(int64(0)), reflect.TypeOf(uint64(0)), reflect.TypeOf(0.0), reflect.TypeOf(false),
+	reflect.TypeOf(uuid.UUID{}), reflect.TypeOf(time.Time{}), reflect.TypeOf(""),
+}
+
+func FuzzEncodeDecode(f *testing.F) {
+	// Seeds derived from TestShouldEncodePartsIntoLexKey
+	f.Add(int64(123), uint64(123), 3.14, true, []byte("550e8400e29b41d4"), int64(0), "hello")
+	f.Add(int64(-123), uint64(0), -3.14, false, make([]byte, 16), int64(1700000000000000000), "")
+	f.Add(int64(math.MinInt64), uint64(math.MaxUint64), math.Inf(-1), true, []byte{0xff}, int64(-1), "a\x00b")
+	f.Add(int64(math.MaxInt64), uint64(1), math.NaN(), false, []byte{}, int64(math.MaxInt64), "\xff\xfe")
+
+	f.Fuzz(func(t *testing.T, i int64, u uint64, fl float64, b bool, idBytes []byte, nanos int64, s string) {
+		var id uuid.UUID
+		copy(id[:], idBytes)
+		ts := time.Unix(0, nanos).UTC()
+
+		key, err := NewLexKey(i, u, fl, b, id, ts, s)
+		require.NoError(t, err)
+
+		values, err := Decode(key, fuzzSchema...)
+		require.NoError(t, err)
+		assert.Equal(t, i, values[0])
+		assert.Equal(t, u, values[1])
+		switch {
+		case math.IsNaN(fl):
+			assert.True(t, math.IsNaN(values[2].(float64)))
+		default:
+			assert.Equal(t, fl+0, values[2]) // +0 folds -0.0 into +0.0 like the encoder
+		}
+		assert.Equal(t, b, values[3])
+		assert.Equal(t, id, values[4])
+		assert.Equal(t, ts, values[5])
+		assert.Equal(t, s, values[6])
+	})
+}
+
+// fuzzDecodeValues holds one value of every type Decode supports; FuzzDecode decodes
+// arbitrary bytes as each of their types, alone and before another part, and seeds the corpus
+// with their encodings. Add a value here whenever a new type becomes decodable.
+var fuzzDecodeValues = []any{
+	nil, "s", []byte("b"), LexKey("k"), true, int8(-1), int64(-42), uint16(7), uint64(42),
+	float32(1.5), 2.5, Float16(0x3C00), NonNeg(5), TriTrue, struct{}{},
+	uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), netip.MustParseAddr("192.0.2.1"),
+	time.Unix(1700000000, 0).UTC(), ZonedTime(time.Unix(1700000000, 0).In(time.FixedZone("", 3600))),
+	time.Duration(9), LengthPrefixed("a\x00b"), Decimal{Coefficient: -15, Exponent: -1},
+	big.NewRat(3, 8), [4]byte{1, 0, 2, 0xff}, time.March,
+}
+
+func FuzzDecode(f *testing.F) {
+	f.Add([]byte(Encode(int64(42), "x")))
+	f.Add([]byte(Encode("user", 42, true)))
+	f.Add([]byte{})
+	f.Add([]byte{0x00})
+	f.Add([]byte{0x03, 0xff, 0xff, 0xff, 0xf0, '1'}) // huge decimal exponent
+
+	schemas := [][]reflect.Type{
+		fuzzSchema,
+		{reflect.TypeOf(""), reflect.TypeOf(int64(0)), reflect.TypeOf(false)},
+		{reflect.TypeOf(int8(0)), nil, reflect.TypeOf(struct{}{}), reflect.TypeOf([]byte{})},
+		{reflect.TypeOf(Float16(0)), reflect.TypeOf(float32(0)), reflect.TypeOf(uint16(0))},
+	}
+	for _, v := range fuzzDecodeValues {
+		typ := reflect.TypeOf(v)
+		schemas = append(schemas, []reflect.Type{typ}, []reflect.Type{typ, reflect.TypeOf(int64(0))})
+		f.Add([]byte(Encode(v)))
+		f.Add([]byte(Encode(v, int64(1))))
+	}
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		for _, schema := range schemas {
+			// Must never panic on malformed input
+			_, _ = Decode(raw, schema...)
+			_, _ = DecodeAt[int64](raw, 1, schema...)
+			_, _, _ = DecodeOne(raw, schema[0])
+		}
+	})
+}
+
+func FuzzFromHexString(f *testing.F) {
+	// Seeds derived from the hex and JSON table tests
+	for _, s := range []string{"", "68656c6c6f", "6", "zz", "0xff", "800000000000002a", `"68656c6c6f"`, "null", `"`, `""`} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		var k LexKey
+		if err := k.FromHexString(s); err == nil {
+			assert.Equal(t, s == "", len(k) == 0)
+			assert.Equal(t, len(s)/2, len(k))
+		}
+
+		var j LexKey
+		if err := j.UnmarshalJSON([]byte(s)); err == nil {
+			data, err := j.MarshalJSON()
+			require.NoError(t, err)
+			var back LexKey
+			require.NoError(t, back.UnmarshalJSON(data))
+			assert.Equal(t, j, back)
+		}
+
+		var txt LexKey
+		_ = txt.UnmarshalText([]byte(s))
+	})
+}
@@ -0,0 +1,49 @@
+package lexkey
+
+import "math"
+
+// geoMaxBits is the number of interleaved bits available: 32 each for latitude and longitude.
+const geoMaxBits = 64
+
+// EncodeGeo encodes a latitude/longitude point as a geohash-style key of precision bits, so
+// points close to each other usually share a long prefix and a prefix scan covers a
+// rectangular cell. Each coordinate is quantized to 32 bits over its range (-90..90 for
+// latitude, -180..180 for longitude), and the bits are interleaved starting with longitude,
+// as in a geohash; the first precision bits are packed most significant first, with unused
+// low bits zero. At 64 bits cells are about 1 cm across; 25 bits (≈ a 5-character geohash)
+// are about 5 km.
+//
+// Precision is clamped to 1..64, coordinates are clamped to their range, and NaN counts as
+// the minimum. Only compare keys of the same precision. As with any space-filling curve,
+// points just either side of a cell boundary can share only a short prefix.
+func EncodeGeo(lat, lng float64, precision int) LexKey {
+	precision = min(max(precision, 1), geoMaxBits)
+	latBits := quantizeGeo(lat, 90)
+	lngBits := quantizeGeo(lng, 180)
+
+	var hash uint64
+	for i := 31; i >= 0; i-- {
+		hash = hash<<1 | uint64(lngBits>>i&1)
+		hash = hash<<1 | uint64(latBits>>i&1)
+	}
+	hash &^= math.MaxUint64 >> precision
+
+	out := make(LexKey, (precision+7)/8)
+	for i := range out {
+		out[i] = byte(hash >> (56 - 8*i))
+	}
+	return out
+}
+
+// quantizeGeo maps v in -limit..limit onto 0..2^32-1, clamping values outside the range.
+func quantizeGeo(v, limit float64) uint32 {
+	if math.IsNaN(v) || v <= -limit {
+		return 0
+	}
+	// Values just below limit can round up to exactly 2^32, which would wrap to 0.
+	scaled := (v + limit) / (2 * limit) * (1 << 32)
+	if scaled >= math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return uint32(scaled)
+}
@@ -0,0 +1,99 @@
+package lexkey
+
+import (
+	"math"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldShareLongerPrefixForNearbyPoints(t *testing.T) {
+	// Arrange: two points in Paris about 300 m apart, and one in New York
+	louvre := EncodeGeo(48.8606, 2.3376, 64)
+	orsay := EncodeGeo(48.8600, 2.3266, 64)
+	newYork := EncodeGeo(40.7128, -74.0060, 64)
+
+	// Act
+	nearby := len(CommonPrefix(louvre, orsay))
+	distant := len(CommonPrefix(louvre, newYork))
+
+	// Assert
+	assert.Greater(t, nearby, distant)
+	assert.GreaterOrEqual(t, nearby, 3)
+}
+
+func TestShouldMatchGeohashBitsForKnownPoint(t *testing.T) {
+	// Arrange: the geohash of (57.64911, 10.40744) is "u4pruydqqvj"; its first five characters
+	// "u4pru" are the bits 11010 00100 10101 10111 11010
+	key := EncodeGeo(57.64911, 10.40744, 25)
+
+	// Assert: 25 bits packed into 4 bytes, unused low bits zero
+	test.AssertHexEqual(t, "d12b7d00", key)
+}
+
+func TestShouldClampGeoPrecisionAndCoordinates(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      LexKey
+		expected string
+	}{
+		{"minimum corner", EncodeGeo(-90, -180, 8), "00"},
+		{"maximum corner", EncodeGeo(90, 180, 64), "ffffffffffffffff"},
+		{"out of range clamps", EncodeGeo(1000, 1000, 8), "ff"},
+		{"precision below one", EncodeGeo(90, 180, 0), "80"},
+		{"precision above 64", EncodeGeo(90, 180, 100), "ffffffffffffffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.AssertHexEqual(t, tt.expected, tt.key)
+		})
+	}
+}
+
+func TestShouldKeepLowerPrecisionAsPrefixOfHigher(t *testing.T) {
+	// Arrange
+	coarse := EncodeGeo(48.8606, 2.3376, 16)
+	fine := EncodeGeo(48.8606, 2.3376, 64)
+
+	// Assert
+	assert.Equal(t, fine[:2], coarse)
+}
+
+func TestShouldQuantizeGeoEdgesWithoutWrapping(t *testing.T) {
+	tests := []struct {
+		name     string
+		v, limit float64
+		expected uint32
+	}{
+		{"latitude minimum", -90, 90, 0},
+		{"latitude just above minimum", math.Nextafter(-90, 0), 90, 0},
+		{"latitude just below maximum", math.Nextafter(90, 0), 90, math.MaxUint32},
+		{"latitude maximum", 90, 90, math.MaxUint32},
+		{"longitude minimum", -180, 180, 0},
+		{"longitude just above minimum", math.Nextafter(-180, 0), 180, 0},
+		{"longitude just below maximum", math.Nextafter(180, 0), 180, math.MaxUint32},
+		{"longitude maximum", 180, 180, math.MaxUint32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := quantizeGeo(tt.v, tt.limit)
+
+			// Assert
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestShouldSortNorthEastCornerAfterSouthWestCorner(t *testing.T) {
+	// Act
+	northEast := EncodeGeo(math.Nextafter(90, 0), math.Nextafter(180, 0), 64)
+	southWest := EncodeGeo(math.Nextafter(-90, 0), math.Nextafter(-180, 0), 64)
+
+	// Assert
+	test.AssertHexEqual(t, "ffffffffffffffff", northEast)
+	test.AssertHexEqual(t, "0000000000000000", southWest)
+}
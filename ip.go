@@ -0,0 +1,48 @@
+package lexkey
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// ipSize is the width of an encoded netip.Addr: every address is stored in its 16-byte form.
+const ipSize = 16
+
+// encodeAddr returns the normalized 16-byte form of a: IPv4 addresses become IPv4-mapped IPv6
+// addresses (::ffff:a.b.c.d), so all addresses sort by numeric value within one space and
+// 1.2.3.4 and ::ffff:1.2.3.4 encode identically. Zones are dropped.
+func encodeAddr(a netip.Addr) []byte {
+	b := a.As16()
+	return b[:]
+}
+
+// decodeAddr reverses encodeAddr, returning IPv4-mapped addresses as plain IPv4.
+func decodeAddr(seg []byte) (netip.Addr, error) {
+	if len(seg) != ipSize {
+		return netip.Addr{}, fmt.Errorf("invalid IP length %d", len(seg))
+	}
+	return netip.AddrFrom16([ipSize]byte(seg)).Unmap(), nil
+}
+
+// CIDRRange returns the half-open bounds [lower, upper) covering every key whose first part is
+// a netip.Addr inside the CIDR block, e.g. "10.0.0.0/24" or "2001:db8::/64". lower is the
+// block's first address and upper sorts after its last address and all of that address's
+// extensions, as with EncodeLast. Host bits set in cidr are ignored. Returns an error if
+// cidr does not parse.
+func CIDRRange(cidr string) (lower, upper LexKey, err error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot parse CIDR: %w", err)
+	}
+	prefix = prefix.Masked()
+	bits := prefix.Bits()
+	if prefix.Addr().Is4() {
+		bits += 96 // the IPv4-mapped prefix ::ffff:0:0/96
+	}
+	first := encodeAddr(prefix.Addr())
+	last := append(LexKey{}, first...)
+	for i := bits; i < ipSize*8; i++ {
+		last[i/8] |= 0x80 >> (i % 8)
+	}
+	return LexKey(first), append(last, EndMarker), nil
+}
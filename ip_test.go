@@ -0,0 +1,78 @@
+package lexkey
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldEncodeAddrsAsNormalized16Bytes(t *testing.T) {
+	// Arrange
+	v4 := netip.MustParseAddr("10.0.0.1")
+	mapped := netip.MustParseAddr("::ffff:10.0.0.1")
+
+	// Act / Assert
+	test.AssertHexEqual(t, "00000000000000000000ffff0a000001", Encode(v4))
+	assert.Equal(t, Encode(v4), Encode(mapped))
+	assert.Equal(t, Encode(nil), Encode(netip.Addr{}))
+	assert.Negative(t, Compare(Encode(netip.MustParseAddr("10.0.0.2")), Encode(netip.MustParseAddr("10.0.1.0"))))
+}
+
+func TestShouldDecodeAddr(t *testing.T) {
+	// Arrange
+	v4 := netip.MustParseAddr("192.168.1.10")
+	v6 := netip.MustParseAddr("2001:db8::1")
+
+	// Act
+	values, err := Decode(Encode(v4, v6), addrType, addrType)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{v4, v6}, values)
+}
+
+func TestShouldBoundAddressesInCIDRBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		cidr    string
+		inside  []string
+		outside []string
+	}{
+		{"ipv4 /24", "10.0.0.0/24", []string{"10.0.0.0", "10.0.0.1", "10.0.0.255"}, []string{"9.255.255.255", "10.0.1.0"}},
+		{"ipv4 host bits ignored", "10.0.0.77/24", []string{"10.0.0.0", "10.0.0.255"}, []string{"10.0.1.0"}},
+		{"ipv6 /64", "2001:db8:0:1::/64", []string{"2001:db8:0:1::", "2001:db8:0:1:ffff:ffff:ffff:ffff"},
+			[]string{"2001:db8:0:0:ffff:ffff:ffff:ffff", "2001:db8:0:2::"}},
+		{"ipv4 /32", "10.0.0.5/32", []string{"10.0.0.5"}, []string{"10.0.0.4", "10.0.0.6"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			lower, upper, err := CIDRRange(tt.cidr)
+			require.NoError(t, err)
+			bounds := Bounds{Lower: lower, Upper: upper}
+
+			// Assert
+			for _, s := range tt.inside {
+				addr := netip.MustParseAddr(s)
+				assert.True(t, bounds.Contains(Encode(addr)), "%s should be inside", s)
+				assert.True(t, bounds.Contains(Encode(addr, "port", 443)), "%s extension should be inside", s)
+			}
+			for _, s := range tt.outside {
+				assert.False(t, bounds.Contains(Encode(netip.MustParseAddr(s))), "%s should be outside", s)
+			}
+		})
+	}
+}
+
+func TestShouldRejectInvalidCIDR(t *testing.T) {
+	// Act
+	_, _, err := CIDRRange("10.0.0.0/33")
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot parse CIDR")
+}
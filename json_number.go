@@ -0,0 +1,72 @@
+package lexkey
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// JSONNumberAsInt forces a json.Number part to be encoded as int64.
+// Integral values written with a fraction or exponent (e.g. "42.0", "1e3") are accepted;
+// non-integral or out-of-range values return an error.
+type JSONNumberAsInt json.Number
+
+// JSONNumberAsFloat forces a json.Number part to be encoded as float64,
+// even when the value is integral (e.g. "42" encodes like 42.0).
+type JSONNumberAsFloat json.Number
+
+// jsonNumberValue resolves a json.Number into the value it encodes as.
+// Policy: integral values that fit in int64 become int64 (so "42" and "42.0" encode like 42);
+// everything else becomes float64. Int and float encodings do not interleave, so callers
+// mixing both in one key position should force a single representation with
+// JSONNumberAsInt or JSONNumberAsFloat.
+func jsonNumberValue(n json.Number) (any, error) {
+	if i, err := n.Int64(); err == nil {
+		return i, nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("invalid json.Number %q: %w", string(n), err)
+	}
+	if i, ok := float64ToInt64(f); ok {
+		return i, nil
+	}
+	return f, nil
+}
+
+// jsonNumberInt parses n as an integral int64.
+func jsonNumberInt(n json.Number) (int64, error) {
+	if i, err := n.Int64(); err == nil {
+		return i, nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return 0, fmt.Errorf("invalid json.Number %q: %w", string(n), err)
+	}
+	i, ok := float64ToInt64(f)
+	if !ok {
+		return 0, fmt.Errorf("json.Number %q is not an integral int64", string(n))
+	}
+	return i, nil
+}
+
+// jsonNumberFloat parses n as a float64.
+func jsonNumberFloat(n json.Number) (float64, error) {
+	f, err := n.Float64()
+	if err != nil {
+		return 0, fmt.Errorf("invalid json.Number %q: %w", string(n), err)
+	}
+	return f, nil
+}
+
+// float64ToInt64 converts f to int64 when it is integral and within range.
+func float64ToInt64(f float64) (int64, bool) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f) {
+		return 0, false
+	}
+	// 2^63 is exactly representable; anything >= it overflows int64.
+	if f < math.MinInt64 || f >= -math.MinInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
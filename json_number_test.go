@@ -0,0 +1,79 @@
+package lexkey
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldEncodeJSONNumberUsingIntOrFloatPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    json.Number
+		expected LexKey
+	}{
+		{"integral", json.Number("42"), Encode(int64(42))},
+		{"integral with fraction", json.Number("42.0"), Encode(int64(42))},
+		{"negative fraction", json.Number("-3.14"), Encode(-3.14)},
+		{"exponent", json.Number("1e3"), Encode(int64(1000))},
+		{"beyond int64", json.Number("1e19"), Encode(1e19)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := NewLexKey(tt.input)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestShouldForceJSONNumberRepresentation(t *testing.T) {
+	// Arrange / Act
+	asFloat := Encode(JSONNumberAsFloat("42"))
+	asInt := Encode(JSONNumberAsInt("42.0"))
+
+	// Assert
+	assert.Equal(t, Encode(42.0), asFloat)
+	assert.Equal(t, Encode(42), asInt)
+	test.AssertHexEqual(t, "800000000000002a", asInt)
+}
+
+func TestShouldErrorWhenJSONNumberIsInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+	}{
+		{"malformed", json.Number("4x2")},
+		{"forced int not integral", JSONNumberAsInt("-3.14")},
+		{"forced int overflow", JSONNumberAsInt("1e19")},
+		{"forced float malformed", JSONNumberAsFloat("abc")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := NewLexKey("prefix", tt.input)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestShouldSortJSONNumbersWithinSameRepresentation(t *testing.T) {
+	// Arrange
+	a := Encode(JSONNumberAsFloat("-3.14"))
+	b := Encode(JSONNumberAsFloat("42"))
+	c := Encode(JSONNumberAsFloat("42.5"))
+
+	// Act / Assert
+	assert.Less(t, Compare(a, b), 0)
+	assert.Less(t, Compare(b, c), 0)
+}
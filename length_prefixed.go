@@ -0,0 +1,32 @@
+package lexkey
+
+import "encoding/binary"
+
+// LengthPrefixed marks a byte slice part to be encoded with a 4-byte big-endian length
+// before its contents, like the variable-length fields of EncodeFixedSchema. The decoder
+// reads exactly that many bytes, so the part may contain 0x00 bytes anywhere in a key
+// without escaping, unlike a raw []byte part, which ends at the next Separator.
+//
+// Ordering: length-prefixed parts sort by length first, then by content, so they do not sort
+// like raw []byte parts; only compare them with other LengthPrefixed parts.
+type LengthPrefixed []byte
+
+// encodeLengthPrefixed writes the length of b as a big-endian uint32 followed by b.
+func encodeLengthPrefixed(dst []byte, b LengthPrefixed) (int, error) {
+	if err := checkLengthPrefix(len(b)); err != nil {
+		return 0, err
+	}
+	binary.BigEndian.PutUint32(dst, uint32(len(b)))
+	return lengthPrefixSize + copy(dst[lengthPrefixSize:], b), nil
+}
+
+// lengthPrefixedWidth returns the width of the length-prefixed part at the start of b, prefix
+// included, or len(b)+1 when the prefix claims more bytes than b holds. The length is checked
+// before converting to int, which would go negative above MaxInt32 on 32-bit platforms.
+func lengthPrefixedWidth(b []byte) int {
+	n := binary.BigEndian.Uint32(b)
+	if uint64(n) > uint64(len(b)-lengthPrefixSize) {
+		return len(b) + 1
+	}
+	return lengthPrefixSize + int(n)
+}
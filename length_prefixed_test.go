@@ -0,0 +1,79 @@
+package lexkey
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldEncodeLengthPrefixedBytes(t *testing.T) {
+	// Act
+	key := Encode(LengthPrefixed{0x01, 0x00, 0x02})
+
+	// Assert
+	test.AssertHexEqual(t, "00000003010002", key)
+}
+
+func TestShouldDecodeLengthPrefixedBlobBetweenOtherFields(t *testing.T) {
+	// Arrange
+	blob := LengthPrefixed{0x00, 0xff, 0x00, 0x00, 0x7f}
+	key := Encode("tenant", blob, int64(42))
+	schema := []reflect.Type{stringType, reflect.TypeOf(LengthPrefixed{}), int64Type}
+
+	// Act
+	values, err := Decode(key, schema...)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{"tenant", blob, int64(42)}, values)
+}
+
+func TestShouldDecodeEmptyLengthPrefixedBlob(t *testing.T) {
+	// Arrange
+	key := Encode(LengthPrefixed{}, "after")
+
+	// Act
+	values, err := Decode(key, reflect.TypeOf(LengthPrefixed{}), stringType)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{LengthPrefixed{}, "after"}, values)
+}
+
+func TestShouldSortLengthPrefixedByLengthThenContent(t *testing.T) {
+	// Arrange
+	short := Encode(LengthPrefixed{0xff})
+	longLow := Encode(LengthPrefixed{0x00, 0x00})
+	longHigh := Encode(LengthPrefixed{0x00, 0x01})
+
+	// Act / Assert
+	assert.Negative(t, Compare(short, longLow))
+	assert.Negative(t, Compare(longLow, longHigh))
+}
+
+func TestShouldErrorWhenLengthPrefixExceedsKey(t *testing.T) {
+	// Arrange
+	key := LexKey{0x00, 0x00, 0x00, 0x09, 0x01}
+
+	// Act
+	_, err := Decode(key, reflect.TypeOf(LengthPrefixed{}))
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestShouldRejectLengthPrefixLongerThanInput(t *testing.T) {
+	// Arrange: a length with the top bit set, which is negative as a 32-bit int
+	key := LexKey{0x80, 0x00, 0x00, 0x04, 'a', 'b'}
+
+	// Act
+	_, err := Decode(key, reflect.TypeOf(LengthPrefixed{}))
+	_, _, errOne := DecodeOne(key, reflect.TypeOf(LengthPrefixed{}))
+
+	// Assert
+	require.Error(t, err)
+	require.Error(t, errOne)
+}
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
-	"errors"
+	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -35,6 +39,22 @@ func NewLexKey(parts ...any) (LexKey, error) {
 	return NewLexKeyCanonicalWidth(parts...)
 }
 
+// NewLexKeyCap is like NewLexKey but allocates at least capacity bytes of backing storage,
+// so appending to the key afterwards (e.g. a range marker or further parts) does not reallocate.
+// The exact encoded size is always computed from the parts before the single allocation,
+// so capacity only needs to cover the planned appends; smaller values are ignored.
+func NewLexKeyCap(capacity int, parts ...any) (LexKey, error) {
+	return defaultEncoder.NewLexKeyCap(capacity, parts...)
+}
+
+// EncodeSingle encodes exactly one value as a key, with no separator handling: the result
+// equals NewLexKey(v). It states the intent of single-field index keys and skips the
+// composite-key bookkeeping. Passing a []any (e.g. a forwarded parts slice) is an error
+// rather than an attempt to encode the slice; use NewLexKey for composite keys.
+func EncodeSingle(v any) (LexKey, error) {
+	return defaultEncoder.EncodeSingle(v)
+}
+
 // Encode constructs a LexKey from pre-validated parts, panicking if encoding fails.
 // Use this when inputs are guaranteed to be valid (e.g., no unsupported types).
 // For fallible construction, use NewLexKey instead.
@@ -77,32 +97,88 @@ func canonicalizeNumericWidth(v any) any {
 	}
 }
 
+// canonicalizePart prepares a part for encoding: numeric widths are canonicalized,
+// pointers are dereferenced (a nil pointer encodes like nil, i.e. the Separator byte), and
+// named types without a dedicated encoding (e.g. type Status int) fall back to their kind:
+// integers to int64/uint64, floats to float64, strings, bools and byte slices as-is.
+// Fixed-size byte arrays (e.g. [16]byte) encode as their raw bytes, like uuid.UUID.
+// netip.Addr values become their normalized 16-byte form (the zero Addr becomes nil).
+// NonNeg values become uint64 (negative ones are kept and rejected during encoding).
+// *big.Rat and big.Rat values become their decimal digits and exponent, computed once for
+// both sizing and encoding; a nil *big.Rat becomes nil.
+// database/sql Null types encode as nil when not Valid and as their value otherwise.
+// Types that remain unsupported are returned unchanged and rejected during encoding.
+func canonicalizePart(v any) any {
+	switch x := v.(type) {
+	case nil, string, []byte, LexKey, uuid.UUID, bool, int64, uint64, float64, time.Time, time.Duration,
+		struct{}, json.Number, JSONNumberAsInt, JSONNumberAsFloat, Float16, Decimal, ZonedTime,
+		LengthPrefixed, TriBool, ratDecimal:
+		return v
+	case int, int8, int16, int32, uint8, uint16, uint32, float32:
+		return canonicalizeNumericWidth(v)
+	case CaseFold:
+		return foldCase(string(x))
+	case netip.Addr:
+		if !x.IsValid() {
+			return nil
+		}
+		return encodeAddr(x)
+	case NonNeg:
+		if x < 0 {
+			return x // rejected during encoding
+		}
+		return uint64(x)
+	case *big.Rat:
+		if x == nil {
+			return nil
+		}
+		return newRatDecimal(x)
+	case big.Rat:
+		return newRatDecimal(&x)
+	}
+	if inner, ok := sqlNullValue(v); ok {
+		return canonicalizePart(inner)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil
+		}
+		return canonicalizePart(rv.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv.Bytes()
+		}
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return b
+		}
+	case reflect.Struct:
+		if inner, ok := sqlGenericNullValue(rv); ok {
+			return canonicalizePart(inner)
+		}
+	}
+	return v
+}
+
 // NewLexKeyCanonicalWidth constructs a LexKey after normalizing numeric widths
 // so cross-width numeric values sort logically. Backwards-compatible: bytes differ
 // from NewLexKey because widths are canonicalized; use only if you control both sides.
 func NewLexKeyCanonicalWidth(parts ...any) (LexKey, error) {
-	if len(parts) == 0 {
-		return LexKey([]byte{}), errors.New("cannot create LexKey: no parts provided")
-	}
-	canon := make([]any, len(parts))
-	for i, p := range parts {
-		canon[i] = canonicalizeNumericWidth(p)
-	}
-	size := estimateSize(canon)
-	result := make([]byte, size)
-	pos := 0
-	for i, part := range canon {
-		n, err := encodeInto(result[pos:], part)
-		if err != nil {
-			return LexKey([]byte{}), fmt.Errorf("cannot encode part %d (%T): %w", i, part, err)
-		}
-		pos += n
-		if i < len(canon)-1 {
-			result[pos] = Separator
-			pos++
-		}
-	}
-	return result[:pos], nil
+	return defaultEncoder.NewLexKey(parts...)
 }
 
 // EncodeCanonicalWidth panics on error; see NewLexKeyCanonicalWidth.
@@ -121,55 +197,57 @@ func EncodeSizeCanonicalWidth(parts ...any) int {
 	}
 	canon := make([]any, len(parts))
 	for i, p := range parts {
-		canon[i] = canonicalizeNumericWidth(p)
+		canon[i] = canonicalizePart(p)
 	}
 	return estimateSize(canon)
 }
 
 // EncodeIntoCanonicalWidth writes the canonical-width encoding into dst.
 func EncodeIntoCanonicalWidth(dst []byte, parts ...any) (int, error) {
-	if len(parts) == 0 {
-		return 0, nil
-	}
-	canon := make([]any, len(parts))
-	for i, p := range parts {
-		canon[i] = canonicalizeNumericWidth(p)
-	}
-	need := estimateSize(canon)
-	if len(dst) < need {
-		return 0, fmt.Errorf("EncodeInto: dst too small: need %d bytes, have %d", need, len(dst))
-	}
-	pos := 0
-	for i, part := range canon {
-		n, err := encodeInto(dst[pos:], part)
-		if err != nil {
-			return 0, fmt.Errorf("cannot encode part %d (%T): %w", i, part, err)
-		}
-		pos += n
-		if i < len(canon)-1 {
-			dst[pos] = Separator
-			pos++
-		}
-	}
-	return pos, nil
+	return defaultEncoder.EncodeInto(dst, parts...)
 }
 
 // EncodeFirst returns the first lexicographically sortable key in a range.
 // Adds a Separator byte to the prefix to ensure it sorts before any extension.
 //
+// Every extension of the prefix, Encode(parts..., more...), starts with the prefix followed
+// by a Separator, so EncodeFirst <= extension < EncodeLast holds whatever bytes the prefix
+// or the extra parts contain, including 0x00 and 0xFF inside strings. LexKey does not escape
+// data, so the converse does not hold: a key whose last prefix part merely starts with the
+// prefix's bytes plus a low byte (e.g. Encode("a\x01") for prefix "a") also falls inside.
+//
 // Note: this calls Encode and will panic on encoding errors (i.e., when given unsupported types).
 func EncodeFirst(parts ...any) LexKey {
-	prefix := Encode(parts...)
-	return append(prefix, Separator)
+	return defaultEncoder.EncodeFirst(parts...)
 }
 
 // EncodeLast returns the last lexicographically sortable key in a range.
 // Adds an EndMarker byte to the prefix to ensure it sorts after any extension.
+// See EncodeFirst for how the bounds behave when parts contain marker bytes.
 //
 // Note: this calls Encode and will panic on encoding errors (i.e., when given unsupported types).
 func EncodeLast(parts ...any) LexKey {
-	prefix := Encode(parts...)
-	return append(prefix, EndMarker)
+	return defaultEncoder.EncodeLast(parts...)
+}
+
+// Bytes returns the key as a plain byte slice for storage APIs. It is not copied: the result
+// shares the key's backing array, so modifying one modifies the other.
+func (e LexKey) Bytes() []byte {
+	return []byte(e)
+}
+
+// AppendTo appends the key's bytes to dst and returns the extended slice, following the
+// append idiom of strconv.AppendInt and friends, e.g. to write several keys into one buffer.
+// The result never aliases e.
+func (e LexKey) AppendTo(dst []byte) []byte {
+	return append(dst, e...)
+}
+
+// FromBytes returns a LexKey holding a copy of b, so later changes to b (e.g. a reused
+// iterator buffer) do not affect the key. A nil b yields an empty, non-nil key.
+// Use LexKey(b) to wrap b without copying.
+func FromBytes(b []byte) LexKey {
+	return append(LexKey{}, b...)
 }
 
 // IsEmpty checks if the LexKey is empty (length 0). A nil LexKey is considered empty.
@@ -177,6 +255,24 @@ func (e LexKey) IsEmpty() bool {
 	return len(e) == 0
 }
 
+// Hash returns the 64-bit FNV-1a hash of the key's bytes, the same value hash/fnv's New64a
+// produces, without allocating. Equal keys hash equally, and the value is stable across
+// processes and releases, so it can pick a shard (e.g. Hash() % n) or seed a bloom filter.
+// A nil and an empty key hash equally. FNV is not cryptographic; do not use it where
+// adversarial collisions matter.
+func (e LexKey) Hash() uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	h := uint64(offset64)
+	for _, c := range e {
+		h ^= uint64(c)
+		h *= prime64
+	}
+	return h
+}
+
 // ToHexString converts the LexKey to a hexadecimal string.
 // Returns an empty string for an empty or nil LexKey.
 func (e LexKey) ToHexString() string {
@@ -192,6 +288,16 @@ func EncodeSize(parts ...any) int {
 	return EncodeSizeCanonicalWidth(parts...)
 }
 
+// EncodedSize returns the exact length in bytes of the key NewLexKey would build from parts,
+// including separators, without building it: fixed-width types have known sizes and
+// variable-width ones use their length. Unlike EncodeSize it reports ErrEmptyKey for no parts
+// and an UnsupportedTypeError for parts NewLexKey would reject by type, so a nil error means
+// the size is exact. Values are not validated, so e.g. a malformed json.Number still fails
+// only when encoded.
+func EncodedSize(parts ...any) (int, error) {
+	return defaultEncoder.EncodedSize(parts...)
+}
+
 // EncodeInto writes the encoding of parts into dst and returns the number of bytes written.
 // The dst slice must have length >= EncodeSize(parts...). No allocations are performed.
 func EncodeInto(dst []byte, parts ...any) (int, error) {
@@ -208,15 +314,28 @@ func (e *LexKey) FromHexString(hexStr string) error {
 	}
 	bytes, err := hex.DecodeString(hexStr)
 	if err != nil {
-		if errors.Is(err, hex.ErrLength) {
-			return fmt.Errorf("cannot decode hex string: odd length")
-		}
-		return fmt.Errorf("cannot decode hex string: %w", err)
+		return fmt.Errorf("cannot decode LexKey: %w: %w", ErrInvalidHex, err)
 	}
 	*e = bytes
 	return nil
 }
 
+// ParseHex is a lenient FromHexString for keys pasted from logs: it strips an optional
+// 0x/0X prefix and any whitespace (including between bytes, e.g. "68 65 6c") and accepts
+// either letter case. An input that is empty after stripping yields an empty key.
+func ParseHex(s string) (LexKey, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	s = strings.Join(strings.Fields(s), "")
+	var key LexKey
+	if err := key.FromHexString(s); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
 // MarshalJSON encodes LexKey as a hex string for JSON serialization.
 func (e LexKey) MarshalJSON() ([]byte, error) {
 	// Use MarshalText to get hex bytes, then wrap with quotes without extra escaping
@@ -268,81 +387,74 @@ func (e *LexKey) UnmarshalText(text []byte) error {
 	dst := make([]byte, hex.DecodedLen(len(text)))
 	n, err := hex.Decode(dst, text)
 	if err != nil {
-		return fmt.Errorf("cannot decode hex string: %w", err)
+		return fmt.Errorf("cannot decode LexKey: %w: %w", ErrInvalidHex, err)
 	}
 	*e = dst[:n]
 	return nil
 }
 
 // encodeToBytes converts a value to a lexicographically sortable byte representation.
-// Returns an error if the type is unsupported.
+// Named types (e.g. type ID int64), including ones boxed in any or behind pointers, encode by
+// their reflect.Kind via canonicalizePart. Returns an error if the type is unsupported.
 func encodeToBytes(v any) ([]byte, error) {
-	// Backwards-compatible wrapper: allocate a sufficiently large temp buffer and use encodeInto.
-	// Most types encode into at most 16 bytes (UUID) plus separators.
-	buf := make([]byte, 32)
-	// Apply canonical width so encodeToBytes matches default behavior
-	n, err := encodeInto(buf, canonicalizeNumericWidth(v))
+	// Apply the same canonicalization as NewLexKey so encodeToBytes matches default behavior
+	c := canonicalizePart(v)
+	buf := make([]byte, estimateSize([]any{c}))
+	n, err := encodeInto(buf, c)
 	if err != nil {
 		return nil, err
 	}
-	// return a copy of the used portion
-	out := make([]byte, n)
-	copy(out, buf[:n])
-	return out, nil
-}
-
-// fixedWriter is an io.Writer that writes into a fixed backing slice (no grow).
-type fixedWriter struct {
-	dst []byte
-}
-
-func (w *fixedWriter) Write(p []byte) (int, error) {
-	if len(p) > len(w.dst) {
-		return 0, errors.New("fixedWriter: buffer too small")
-	}
-	n := copy(w.dst, p)
-	w.dst = w.dst[n:]
-	return n, nil
+	return buf[:n], nil
 }
 
 // putLexInt64 writes v as 8 big-endian bytes with the lexicographic sign-bit flip.
-// Uses encoding/binary.Write so gosec does not flag signed→unsigned conversions in this package.
+// Uses encoding/binary.Encode so gosec does not flag signed→unsigned conversions in this
+// package; unlike binary.Write it writes into dst directly, without allocating.
 func putLexInt64(dst []byte, v int64) error {
-	var buf [8]byte
-	w := &fixedWriter{dst: buf[:]}
-	if err := binary.Write(w, binary.BigEndian, v); err != nil {
+	if _, err := binary.Encode(dst[:8], binary.BigEndian, v); err != nil {
 		return err
 	}
-	u := binary.BigEndian.Uint64(buf[:])
-	binary.BigEndian.PutUint64(dst, u^0x8000000000000000)
+	dst[0] ^= 0x80
 	return nil
 }
 
 func putLexInt32(dst []byte, v int32) error {
-	var buf [4]byte
-	w := &fixedWriter{dst: buf[:]}
-	if err := binary.Write(w, binary.BigEndian, v); err != nil {
+	if _, err := binary.Encode(dst[:4], binary.BigEndian, v); err != nil {
 		return err
 	}
-	u := binary.BigEndian.Uint32(buf[:])
-	binary.BigEndian.PutUint32(dst, u^0x80000000)
+	dst[0] ^= 0x80
 	return nil
 }
 
 func putLexInt16(dst []byte, v int16) error {
-	var buf [2]byte
-	w := &fixedWriter{dst: buf[:]}
-	if err := binary.Write(w, binary.BigEndian, v); err != nil {
+	if _, err := binary.Encode(dst[:2], binary.BigEndian, v); err != nil {
 		return err
 	}
-	u := binary.BigEndian.Uint16(buf[:])
-	binary.BigEndian.PutUint16(dst, u^0x8000)
+	dst[0] ^= 0x80
 	return nil
 }
 
+// getLexInt64 reverses putLexInt64, reading 8 big-endian bytes and undoing the sign-bit flip.
+// Uses encoding/binary.Read so gosec does not flag unsigned→signed conversions in this package.
+func getLexInt64(src []byte) (int64, error) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], binary.BigEndian.Uint64(src)^0x8000000000000000)
+	var v int64
+	if err := binary.Read(bytes.NewReader(buf[:]), binary.BigEndian, &v); err != nil {
+		return 0, err
+	}
+	return v, nil
+}
+
 // encodeInto writes the lexicographic encoding of v into dst and returns the number of bytes written.
 // dst must be large enough to hold the encoding; caller is responsible for sizing it (estimateSize).
 func encodeInto(dst []byte, v any) (int, error) {
+	return defaultEncoder.encodePart(dst, v)
+}
+
+// encodeValue writes the untagged encoding of a single part using the encoder's marker bytes
+// for nil and struct{}.
+func (enc *Encoder) encodeValue(dst []byte, v any) (int, error) {
 	switch v := v.(type) {
 	case string:
 		n := copy(dst, v)
@@ -391,9 +503,16 @@ func encodeInto(dst []byte, v any) (int, error) {
 		return 1, nil
 	case float64:
 		if math.IsNaN(v) {
-			binary.BigEndian.PutUint64(dst, 0x7FF8000000000001)
+			nan, err := enc.NaN.nanBits64()
+			if err != nil {
+				return 0, err
+			}
+			binary.BigEndian.PutUint64(dst, nan)
 			return 8, nil
 		}
+		if v == 0 {
+			v = 0 // normalize -0.0 to +0.0 so both zeros encode identically
+		}
 		bits := math.Float64bits(v)
 		if v < 0 {
 			bits = ^bits
@@ -404,9 +523,16 @@ func encodeInto(dst []byte, v any) (int, error) {
 		return 8, nil
 	case float32:
 		if math.IsNaN(float64(v)) {
-			binary.BigEndian.PutUint32(dst, 0x7FC00001)
+			nan, err := enc.NaN.nanBits32()
+			if err != nil {
+				return 0, err
+			}
+			binary.BigEndian.PutUint32(dst, nan)
 			return 4, nil
 		}
+		if v == 0 {
+			v = 0 // normalize -0.0 to +0.0 so both zeros encode identically
+		}
 		bits := math.Float32bits(v)
 		if v < 0 {
 			bits = ^bits
@@ -415,6 +541,17 @@ func encodeInto(dst []byte, v any) (int, error) {
 		}
 		binary.BigEndian.PutUint32(dst, bits)
 		return 4, nil
+	case Float16:
+		if v.IsNaN() {
+			nan, err := enc.NaN.nanBits16()
+			if err != nil {
+				return 0, err
+			}
+			binary.BigEndian.PutUint16(dst, nan)
+			return 2, nil
+		}
+		binary.BigEndian.PutUint16(dst, float16OrderedBits(v))
+		return 2, nil
 	case bool:
 		if v {
 			dst[0] = 1
@@ -435,13 +572,43 @@ func encodeInto(dst []byte, v any) (int, error) {
 		}
 		return 8, nil
 	case nil:
-		dst[0] = Separator
+		dst[0] = enc.Separator
 		return 1, nil
 	case struct{}:
-		dst[0] = EndMarker
+		dst[0] = enc.EndMarker
 		return 1, nil
+	case Decimal:
+		return encodeDecimal(dst, v)
+	case ratDecimal:
+		return encodeRat(dst, v)
+	case NonNeg:
+		return 0, errNegativeNonNeg(v)
+	case TriBool:
+		return encodeTriBool(dst, v)
+	case ZonedTime:
+		return encodeZonedTime(dst, time.Time(v))
+	case LengthPrefixed:
+		return encodeLengthPrefixed(dst, v)
+	case json.Number:
+		val, err := jsonNumberValue(v)
+		if err != nil {
+			return 0, err
+		}
+		return enc.encodePart(dst, val)
+	case JSONNumberAsInt:
+		i, err := jsonNumberInt(json.Number(v))
+		if err != nil {
+			return 0, err
+		}
+		return enc.encodePart(dst, i)
+	case JSONNumberAsFloat:
+		f, err := jsonNumberFloat(json.Number(v))
+		if err != nil {
+			return 0, err
+		}
+		return enc.encodePart(dst, f)
 	default:
-		return 0, fmt.Errorf("unsupported type %T", v)
+		return 0, &UnsupportedTypeError{Type: reflect.TypeOf(v)}
 	}
 }
 
@@ -449,35 +616,8 @@ func encodeInto(dst []byte, v any) (int, error) {
 func estimateSize(parts []any) int {
 	size := 0
 	for i, part := range parts {
-		switch v := part.(type) {
-		case string:
-			size += len(v)
-		case uuid.UUID:
-			size += 16
-		case LexKey:
-			size += len(v)
-		case []byte:
-			size += len(v)
-		case int, int64, uint64, time.Time, time.Duration:
-			size += 8
-		case int32, uint32:
-			size += 4
-		case int16, uint16:
-			size += 2
-		case uint8:
-			size++
-		case float64:
-			size += 8
-		case float32:
-			size += 4
-		case bool:
-			size++
-		case nil, struct{}:
-			size++
-		default:
-			// Unsupported types will error later; assume minimal size
-			size++
-		}
+		n, _ := partSize(part)
+		size += n
 		if i < len(parts)-1 {
 			size++ // Separator
 		}
@@ -485,15 +625,59 @@ func estimateSize(parts []any) int {
 	return size
 }
 
+// partSize returns the encoded size of a single canonicalized part, and false if its type
+// is unsupported.
+func partSize(part any) (int, bool) {
+	switch v := part.(type) {
+	case string:
+		return len(v), true
+	case uuid.UUID:
+		return 16, true
+	case LexKey:
+		return len(v), true
+	case []byte:
+		return len(v), true
+	case int, int64, uint64, time.Time, time.Duration, NonNeg:
+		return 8, true
+	case int32, uint32:
+		return 4, true
+	case int16, uint16, Float16:
+		return 2, true
+	case uint8:
+		return 1, true
+	case float64, json.Number, JSONNumberAsInt, JSONNumberAsFloat:
+		return 8, true
+	case float32:
+		return 4, true
+	case bool, TriBool:
+		return 1, true
+	case nil, struct{}:
+		return 1, true
+	case Decimal:
+		return decimalSize(v), true
+	case ratDecimal:
+		return ratSize(v), true
+	case ZonedTime:
+		return zonedTimeSize, true
+	case LengthPrefixed:
+		return lengthPrefixSize + len(v), true
+	}
+	// Unsupported types will error later; assume minimal size
+	return 1, false
+}
+
 // encodeFloat64 encodes a float64 into 8 bytes, ensuring lexicographic ordering.
 // Flips the sign bit for positive numbers and all bits for negative numbers.
-// NaN is encoded as a canonical value (0x7FF8000000000001).
+// NaN is encoded as a canonical value (0x7FF8000000000001); -0.0 encodes like +0.0.
 func encodeFloat64(v float64) []byte {
 	buf := make([]byte, 8)
 	if math.IsNaN(v) {
 		binary.BigEndian.PutUint64(buf, 0x7FF8000000000001) // Canonical NaN
 		return buf
 	}
+	if v == 0 {
+		v = 0 // Normalize -0.0 to +0.0
+	}
 	bits := math.Float64bits(v)
 	if v < 0 {
 		bits = ^bits // Flip all bits for negative numbers
@@ -506,13 +690,16 @@ func encodeFloat64(v float64) []byte {
 
 // encodeFloat32 encodes a float32 into 4 bytes, ensuring lexicographic ordering.
 // Flips the sign bit for positive numbers and all bits for negative numbers.
-// NaN is encoded as a canonical value (0x7FC00001).
+// NaN is encoded as a canonical value (0x7FC00001); -0.0 encodes like +0.0.
 func encodeFloat32(v float32) []byte {
 	buf := make([]byte, 4)
 	if math.IsNaN(float64(v)) {
 		binary.BigEndian.PutUint32(buf, 0x7FC00001) // Canonical NaN
 		return buf
 	}
+	if v == 0 {
+		v = 0 // Normalize -0.0 to +0.0
+	}
 	bits := math.Float32bits(v)
 	if v < 0 {
 		bits = ^bits // Flip all bits for negative numbers
@@ -523,7 +710,190 @@ func encodeFloat32(v float32) []byte {
 	return buf
 }
 
+// decodeFloat64Bits reverses the float64 ordering transform. Every NaN pattern produced by
+// a NaNPolicy decodes as NaN.
+func decodeFloat64Bits(bits uint64) float64 {
+	switch bits {
+	case 0x7FF8000000000001, 0xFFF8000000000000, 0x0007FFFFFFFFFFFF:
+		return math.NaN()
+	}
+	if bits&(1<<63) != 0 {
+		bits ^= 1 << 63 // positive: undo the sign-bit flip
+	} else {
+		bits = ^bits // negative: undo the full inversion
+	}
+	return math.Float64frombits(bits)
+}
+
 // Compare returns -1, 0, 1 for a < b, a == b, a > b respectively without allocations.
 func Compare(a, b LexKey) int {
 	return bytes.Compare(a, b)
 }
+
+// EqualIgnoringBoundary reports whether a and b are equal after stripping a single trailing
+// Separator (0x00, as added by EncodeFirst) or EndMarker (0xFF, as added by EncodeLast) from
+// each, e.g. to detect that a scan hit the key a range bound was built from.
+// The check is ambiguous when a real key ends in 0x00 or 0xFF (e.g. an empty string, nil or
+// struct{} last part, or an integer whose low byte is 0x00): that byte is stripped too, so
+// Encode("a", "") matches Encode("a"). Use bytes.Equal where exact equality matters.
+func EqualIgnoringBoundary(a, b LexKey) bool {
+	return bytes.Equal(trimBoundary(a), trimBoundary(b))
+}
+
+// trimBoundary strips one trailing Separator or EndMarker from k.
+func trimBoundary(k LexKey) LexKey {
+	if n := len(k); n > 0 && (k[n-1] == Separator || k[n-1] == EndMarker) {
+		return k[:n-1]
+	}
+	return k
+}
+
+// Concat joins already-encoded keys with a Separator between each, producing the same bytes
+// as encoding all of their parts together: Concat(Encode("a"), Encode("b")) == Encode("a", "b").
+// Returns an empty key when no keys are given. The result never aliases the inputs.
+func Concat(keys ...LexKey) LexKey {
+	if len(keys) == 0 {
+		return LexKey([]byte{})
+	}
+	size := len(keys) - 1
+	for _, k := range keys {
+		size += len(k)
+	}
+	result := make(LexKey, 0, size)
+	for i, k := range keys {
+		if i > 0 {
+			result = append(result, Separator)
+		}
+		result = append(result, k...)
+	}
+	return result
+}
+
+// Append returns a new key with part encoded after e, separated by a Separator unless e is
+// empty, so chained appends match NewLexKey with the same parts:
+// Empty.Append("a") then .Append(42) equals Encode("a", 42). Because an empty key has no
+// parts, a leading part that encodes to no bytes (e.g. "") is not kept as a separate part.
+// The result never aliases e.
+func (e LexKey) Append(part any) (LexKey, error) {
+	canon := []any{canonicalizePart(part)}
+	n := len(e)
+	if n > 0 {
+		n++ // Separator
+	}
+	result := make(LexKey, n+estimateSize(canon))
+	copy(result, e)
+	if len(e) > 0 {
+		result[len(e)] = Separator
+	}
+	written, err := encodeInto(result[n:], canon[0])
+	if err != nil {
+		return nil, fmt.Errorf("cannot append part (%T): %w", part, err)
+	}
+	return result[:n+written], nil
+}
+
+// WithSeparator returns e followed by a Separator, for assembling keys from pre-encoded
+// components: append(Encode("p").WithSeparator(), row...) matches PrimaryKey.Encode.
+// The bytes equal Next, which is the same key viewed as a scan cursor. The result never aliases e.
+func (e LexKey) WithSeparator() LexKey {
+	result := make(LexKey, len(e)+1)
+	copy(result, e)
+	result[len(e)] = Separator
+	return result
+}
+
+// Next returns the smallest key strictly greater than e: e followed by a 0x00 byte.
+// Use it to resume a forward scan just after a key. The result never aliases e.
+func (e LexKey) Next() LexKey {
+	result := make(LexKey, len(e)+1)
+	copy(result, e)
+	return result
+}
+
+// Prev returns the largest key no longer than e that sorts strictly before it, for
+// resuming a reverse scan. A trailing 0x00 is stripped, which is the exact predecessor and
+// undoes Next; otherwise the last byte is decremented. Keys between a decremented result
+// and e are extensions of the result (e.g. "aa\xff" lies between Prev("ab") == "aa" and "ab"),
+// so reverse scans should treat the result as an inclusive prefix bound.
+// The empty key is the minimum and has no predecessor, so ok is false for it.
+// The result never aliases e.
+func (e LexKey) Prev() (prev LexKey, ok bool) {
+	n := len(e)
+	if n == 0 {
+		return nil, false
+	}
+	if e[n-1] == 0x00 {
+		return append(LexKey{}, e[:n-1]...), true
+	}
+	result := append(LexKey{}, e...)
+	result[n-1]--
+	return result, true
+}
+
+// ExclusiveUpperBound returns the smallest key greater than every key that starts with the
+// bytes of prefix, so [prefix, upper) covers exactly those keys: trailing 0xFF bytes are
+// dropped and the last remaining byte is incremented, e.g. "a\xff" yields "b". Unlike
+// EncodeLast, which only bounds keys extending prefix by whole parts, this bounds any byte
+// extension, including raw "a\xff\xff\x01". When prefix is empty or all 0xFF no such key
+// exists, so ok is false: scan to the end of the keyspace. The result never aliases prefix.
+func ExclusiveUpperBound(prefix LexKey) (upper LexKey, ok bool) {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != EndMarker {
+			upper = append(LexKey{}, prefix[:i+1]...)
+			upper[i]++
+			return upper, true
+		}
+	}
+	return nil, false
+}
+
+// CommonPrefix returns the longest byte prefix shared by a and b, e.g. to find the tightest
+// bound covering a set of keys. The result is a copy and never aliases either input.
+func CommonPrefix(a, b LexKey) LexKey {
+	n := min(len(a), len(b))
+	i := 0
+	for i < n && a[i] == b[i] {
+		i++
+	}
+	return append(LexKey{}, a[:i]...)
+}
+
+// Truncate returns e cut to at most maxLen bytes, for backends that cap key length; keys no
+// longer than maxLen are returned as is, and a negative maxLen is treated as 0. LexKey does
+// not escape data, so any cut leaves a valid byte string, but it may end inside a part (e.g.
+// halfway through an int64) and no longer decode.
+//
+// Truncation changes sort position: the result is a prefix of e, so it sorts at or before e,
+// and distinct keys sharing their first maxLen bytes collide. The result shares e's backing
+// array but has its capacity capped, so appending to it never overwrites e.
+func (e LexKey) Truncate(maxLen int) LexKey {
+	n := min(max(maxLen, 0), len(e))
+	return e[:n:n]
+}
+
+// PrefixParts returns the first n parts of e, without a trailing Separator, as a prefix to
+// scan under: Encode("a", "b", "c").PrefixParts(2) equals Encode("a", "b"). It returns an
+// error if n is less than 1 or e has fewer than n parts. The result shares e's backing array.
+//
+// Parts are found by scanning for Separator bytes, and LexKey does not escape data, so this
+// is only reliable when the first n-1 parts contain no 0x00 bytes (note that most numeric
+// encodings do). Use Decode with a schema when parts may contain 0x00.
+func (e LexKey) PrefixParts(n int) (LexKey, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("PrefixParts: part count %d must be at least 1", n)
+	}
+	seen := 1
+	for i, b := range e {
+		if b != Separator {
+			continue
+		}
+		if seen == n {
+			return e[:i], nil
+		}
+		seen++
+	}
+	if seen < n {
+		return nil, fmt.Errorf("PrefixParts: key has %d parts, fewer than %d", seen, n)
+	}
+	return e, nil
+}
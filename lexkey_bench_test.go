@@ -309,6 +309,19 @@ func BenchmarkUnmarshalJSON(b *testing.B) {
 	}
 }
 
+// BenchmarkJSONRoundTrip benchmarks marshaling a key to JSON and back
+func BenchmarkJSONRoundTrip(b *testing.B) {
+	key := Encode("prefix", 42, uuid.New(), true, 3.14, []byte("data"))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data, _ := key.MarshalJSON()
+		var decoded LexKey
+		_ = decoded.UnmarshalJSON(data)
+	}
+}
+
 // BenchmarkPrimaryKeyEncode benchmarks PrimaryKey encoding
 func BenchmarkPrimaryKeyEncode(b *testing.B) {
 	testCases := []struct {
@@ -509,3 +522,124 @@ func BenchmarkLongStrings(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkKeyAllocation compares building a key by appending each encoded part against
+// the single exact-size allocation of NewLexKey, and shows NewLexKeyCap avoiding a
+// reallocation when a range marker is appended afterwards.
+func BenchmarkKeyAllocation(b *testing.B) {
+	parts := []any{"tenant", "users", 42, uuid.New(), true}
+
+	b.Run("IncrementalAppend", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var key LexKey
+			for j, p := range parts {
+				if j > 0 {
+					key = append(key, Separator)
+				}
+				enc, _ := encodeToBytes(p)
+				key = append(key, enc...)
+			}
+			_ = key
+		}
+	})
+
+	b.Run("NewLexKey", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = NewLexKey(parts...)
+		}
+	})
+
+	b.Run("NewLexKeyThenAppendMarker", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			key, _ := NewLexKey(parts...)
+			_ = append(key, EndMarker)
+		}
+	})
+
+	b.Run("NewLexKeyCapThenAppendMarker", func(b *testing.B) {
+		size := EncodeSize(parts...) + 1
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			key, _ := NewLexKeyCap(size, parts...)
+			_ = append(key, EndMarker)
+		}
+	})
+}
+
+// BenchmarkEncodeBatch compares encoding a table of same-schema rows one NewLexKey call at a
+// time against a single EncodeBatch call.
+func BenchmarkEncodeBatch(b *testing.B) {
+	rows := make([][]any, 1000)
+	for i := range rows {
+		rows[i] = []any{"tenant", "users", i, true}
+	}
+
+	b.Run("NewLexKeyPerRow", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			keys := make([]LexKey, len(rows))
+			for j, row := range rows {
+				keys[j], _ = NewLexKey(row...)
+			}
+		}
+	})
+
+	b.Run("EncodeBatch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = EncodeBatch(rows)
+		}
+	})
+}
+
+// BenchmarkBuilderGrow compares appending parts to a fresh Builder with and without an
+// exact Grow up front; with Grow the only allocation is the buffer itself.
+func BenchmarkBuilderGrow(b *testing.B) {
+	parts := []any{"tenant", "users", "profile", "settings", "notifications"}
+	size, _ := EncodedSize(parts...)
+
+	b.Run("NoGrow", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var kb Builder
+			for _, p := range parts {
+				_ = kb.Append(p)
+			}
+		}
+	})
+
+	b.Run("GrowExact", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var kb Builder
+			kb.Grow(size)
+			for _, p := range parts {
+				_ = kb.Append(p)
+			}
+		}
+	})
+}
+
+// BenchmarkRangeKeyContains compares RangeKey.Contains, which materializes the encoded
+// bounds on every call, with ContainsFast, which compares in place without allocating.
+func BenchmarkRangeKeyContains(b *testing.B) {
+	rk := NewRangeKey(Encode("tenant", "table"), Encode("user1"), Encode("user9"))
+	key := Encode("tenant", "table", "user5")
+
+	b.Run("Contains", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = rk.Contains(key, true)
+		}
+	})
+
+	b.Run("ContainsFast", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = rk.ContainsFast(key, true)
+		}
+	})
+}
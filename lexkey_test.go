@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"math"
 	"testing"
 	"time"
@@ -155,6 +156,44 @@ func TestShouldEncodeLastAfterAnyExtension(t *testing.T) {
 	test.AssertHexGreater(t, last, key)
 }
 
+func TestShouldBracketExtensionsWhenPrefixEndsInMarkerBytes(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix []any
+	}{
+		{"trailing separator byte", []any{"tenant", "a\x00"}},
+		{"trailing end marker byte", []any{"tenant", "a\xff"}},
+		{"only end marker bytes", []any{"\xff\xff"}},
+		{"integer ending in zero bytes", []any{int64(256)}},
+	}
+	extensions := [][]any{{""}, {nil}, {"\x00"}, {"\xff\xff\xff"}, {int64(-1)}, {uint64(math.MaxUint64)}, {struct{}{}}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			first := EncodeFirst(tt.prefix...)
+			last := EncodeLast(tt.prefix...)
+
+			// Act / Assert
+			for _, ext := range extensions {
+				key := Encode(append(append([]any{}, tt.prefix...), ext...)...)
+				test.AssertHexLessOrEqual(t, first, key)
+				test.AssertHexGreater(t, last, key)
+			}
+		})
+	}
+}
+
+func TestShouldIncludeUnescapedLookalikeKeysInPrefixBounds(t *testing.T) {
+	// Arrange: "a\x01" is not an extension of ("a"), but shares its bytes
+	first := EncodeFirst("a")
+	last := EncodeLast("a")
+	lookalike := Encode("a\x01")
+
+	// Act / Assert: documented limitation of unescaped keys
+	assert.True(t, Bounds{Lower: first, Upper: last}.Contains(lookalike))
+}
+
 // PrimaryKey behavior
 func TestShouldEncodePrimaryKeyGivenPartitionAndRow(t *testing.T) {
 	// Arrange
@@ -1013,3 +1052,981 @@ func TestEncodeIntoIntegerWidthsAndTime(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 8, n)
 }
+
+func TestShouldEncodePointersByDereferencing(t *testing.T) {
+	// Arrange
+	var nilInt *int
+	n := 42
+	s := "hello"
+	ts := time.Unix(1700000000, 0)
+	pn := &n
+
+	tests := []struct {
+		name     string
+		input    any
+		expected LexKey
+	}{
+		{"nil *int", nilInt, Encode(nil)},
+		{"*int", &n, Encode(42)},
+		{"*string", &s, Encode("hello")},
+		{"*time.Time", &ts, Encode(ts)},
+		{"**int", &pn, Encode(42)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := NewLexKey("prefix", tt.input)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, Encode("prefix", tt.expected), got)
+		})
+	}
+}
+
+func TestShouldEncodeToBytesGivenPointerToLongString(t *testing.T) {
+	// Arrange
+	s := "a string that is longer than thirty-two bytes"
+
+	// Act
+	bs, err := encodeToBytes(&s)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []byte(s), bs)
+}
+
+func TestShouldErrorWhenPointerTargetIsUnsupported(t *testing.T) {
+	// Arrange
+	m := map[int]int{1: 2}
+
+	// Act
+	_, err := NewLexKey(&m)
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestShouldEncodeNegativeZeroLikePositiveZero(t *testing.T) {
+	// Arrange
+	negZero := math.Copysign(0, -1)
+
+	// Act
+	pos := Encode(0.0)
+	neg := Encode(negZero)
+	pos32 := encodeFloat32(0)
+	neg32 := encodeFloat32(float32(negZero))
+
+	// Assert
+	test.AssertHexEqual(t, "8000000000000000", pos)
+	test.AssertHexEqual(t, "8000000000000000", neg)
+	assert.Equal(t, 0, Compare(pos, neg))
+	assert.Equal(t, encodeFloat64(0), encodeFloat64(negZero))
+	test.AssertHexEqual(t, "80000000", neg32)
+	assert.Equal(t, pos32, neg32)
+	test.AssertHexEqual(t, "8000", Encode(Float16(0x8000)))
+}
+
+func TestShouldSortNegativeZeroBetweenNegativesAndPositives(t *testing.T) {
+	// Arrange
+	negTiny := Encode(-math.SmallestNonzeroFloat64)
+	negZero := Encode(math.Copysign(0, -1))
+	posTiny := Encode(math.SmallestNonzeroFloat64)
+
+	// Act / Assert
+	assert.Less(t, Compare(negTiny, negZero), 0)
+	assert.Less(t, Compare(negZero, posTiny), 0)
+}
+
+func TestShouldConcatKeysLikeEncodingAllParts(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []LexKey
+		expected LexKey
+	}{
+		{"two strings", []LexKey{Encode("a"), Encode("b")}, Encode("a", "b")},
+		{"multi-part components", []LexKey{Encode("tenant", 42), Encode(true, "x")}, Encode("tenant", 42, true, "x")},
+		{"single key", []LexKey{Encode("a")}, Encode("a")},
+		{"empty component", []LexKey{Encode("a"), Encode(""), Encode("b")}, Encode("a", "", "b")},
+		{"no keys", nil, LexKey{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := Concat(tt.keys...)
+
+			// Assert
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestShouldNotAliasInputsWhenConcatenating(t *testing.T) {
+	// Arrange
+	a := Encode("a")
+
+	// Act
+	got := Concat(a)
+	got[0] = 'z'
+
+	// Assert
+	assert.Equal(t, Encode("a"), a)
+}
+
+type testStatus int
+type testLevel uint8
+type testName string
+type testScore float64
+type testBlob []byte
+type testFlag bool
+
+func TestShouldEncodeNamedTypesByKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected LexKey
+	}{
+		{"named int", testStatus(3), Encode(3)},
+		{"named uint8", testLevel(7), Encode(uint8(7))},
+		{"named string", testName("alice"), Encode("alice")},
+		{"named float64", testScore(-1.5), Encode(-1.5)},
+		{"named byte slice", testBlob("raw"), Encode([]byte("raw"))},
+		{"named bool", testFlag(true), Encode(true)},
+		{"pointer to named int", func() any { s := testStatus(3); return &s }(), Encode(3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := NewLexKey("prefix", tt.input)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, Encode("prefix", tt.expected), got)
+		})
+	}
+}
+
+func TestShouldEncodeInterfaceHeldNamedTypesByKind(t *testing.T) {
+	// Arrange: values reach the encoder boxed in any, as from a []any built elsewhere
+	type ID int64
+	var boxed any = ID(-42)
+	var nested any = &boxed
+	parts := []any{ID(1), boxed}
+
+	// Act
+	single, err := encodeToBytes(boxed)
+	viaPointer, errPtr := NewLexKey(nested)
+	viaSlice, errSlice := NewLexKey(parts...)
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, errPtr)
+	require.NoError(t, errSlice)
+	assert.Equal(t, []byte(Encode(int64(-42))), single)
+	assert.Equal(t, Encode(int64(-42)), viaPointer)
+	assert.Equal(t, Encode(int64(1), int64(-42)), viaSlice)
+	assert.Negative(t, Compare(Encode(boxed), Encode(ID(1))))
+}
+
+func TestShouldSortNamedIntegerEnumsByValue(t *testing.T) {
+	// Arrange
+	const (
+		statusPending testStatus = iota - 1
+		statusActive
+		statusClosed
+	)
+
+	// Act
+	pending, active, closed := Encode(statusPending), Encode(statusActive), Encode(statusClosed)
+
+	// Assert
+	assert.Less(t, Compare(pending, active), 0)
+	assert.Less(t, Compare(active, closed), 0)
+}
+
+func TestShouldSortMonthsInCalendarOrder(t *testing.T) {
+	// Arrange
+	keys := make([]LexKey, 0, 12)
+	for m := time.December; m >= time.January; m-- {
+		keys = append(keys, Encode("calendar", m))
+	}
+
+	// Act
+	Sort(keys)
+
+	// Assert
+	for i, k := range keys {
+		assert.Equal(t, Encode("calendar", int64(i+1)), k)
+	}
+	assert.Negative(t, Compare(Encode(time.January), Encode(time.February)))
+}
+
+func TestShouldSortWeekdaysFromSunday(t *testing.T) {
+	// Arrange
+	days := []time.Weekday{time.Saturday, time.Monday, time.Sunday, time.Wednesday}
+	keys := make([]LexKey, len(days))
+	for i, d := range days {
+		keys[i] = Encode(d)
+	}
+
+	// Act
+	Sort(keys)
+
+	// Assert
+	assert.Equal(t, []LexKey{
+		Encode(time.Sunday), Encode(time.Monday), Encode(time.Wednesday), Encode(time.Saturday),
+	}, keys)
+}
+
+func TestShouldKeepDedicatedEncodingsForNamedTypesWithOwnCases(t *testing.T) {
+	// Act / Assert: Float16 and Duration are named integer types with their own encodings
+	assert.Len(t, Encode(Float16(0x3C00)), 2)
+	assert.Equal(t, Encode(int64(42)), Encode(time.Duration(42)))
+}
+
+func TestShouldRejectNamedTypesWithUnsupportedKinds(t *testing.T) {
+	// Arrange
+	type pair struct{ a, b int }
+
+	// Act
+	_, err := NewLexKey(pair{1, 2})
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestShouldEncodeFixedSizeByteArraysAsRawBytes(t *testing.T) {
+	// Arrange
+	id := [16]byte{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x41, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00}
+	tag := [4]byte{0xde, 0xad, 0xbe, 0xef}
+	type rawID [16]byte
+
+	// Act / Assert
+	test.AssertHexEqual(t, "550e8400e29b41d4a716446655440000", Encode(id))
+	test.AssertHexEqual(t, "deadbeef", Encode(tag))
+	assert.Equal(t, Encode(uuid.UUID(id)), Encode(id))
+	assert.Equal(t, Encode(id), Encode(rawID(id)))
+	assert.Equal(t, Encode(id), Encode(&id))
+	assert.Equal(t, Encode(tag[:]), Encode(tag))
+}
+
+func TestShouldSortFixedSizeByteArraysByContents(t *testing.T) {
+	// Arrange
+	a := Encode([4]byte{0x00, 0x00, 0x00, 0x01})
+	b := Encode([4]byte{0x00, 0x00, 0x01, 0x00})
+	c := Encode([4]byte{0xff, 0x00, 0x00, 0x00})
+
+	// Act / Assert
+	assert.Negative(t, Compare(a, b))
+	assert.Negative(t, Compare(b, c))
+}
+
+func TestShouldRejectArraysOfNonByteElements(t *testing.T) {
+	// Act
+	_, err := NewLexKey([2]int{1, 2})
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestShouldComputeCommonPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     LexKey
+		expected LexKey
+	}{
+		{"nothing shared", Encode("abc"), Encode("xyz"), LexKey{}},
+		{"everything shared", Encode("tenant", 42), Encode("tenant", 42), Encode("tenant", 42)},
+		{"partial overlap", Encode("tenant", "users"), Encode("tenant", "orders"), EncodeFirst("tenant")},
+		{"one is prefix", Encode("ab"), Encode("abc"), Encode("ab")},
+		{"empty input", LexKey{}, Encode("a"), LexKey{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := CommonPrefix(tt.a, tt.b)
+
+			// Assert
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestShouldReturnIndependentCopyFromCommonPrefix(t *testing.T) {
+	// Arrange
+	a := Encode("shared")
+	b := Encode("shared")
+
+	// Act
+	prefix := CommonPrefix(a, b)
+	prefix[0] = 'X'
+
+	// Assert
+	assert.Equal(t, Encode("shared"), a)
+	assert.Equal(t, Encode("shared"), b)
+}
+
+func TestShouldReserveCapacityWithNewLexKeyCap(t *testing.T) {
+	// Arrange
+	parts := []any{"tenant", 42}
+
+	// Act
+	key, err := NewLexKeyCap(64, parts...)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Encode(parts...), key)
+	assert.Equal(t, 64, cap(key))
+}
+
+func TestShouldIgnoreCapacityBelowEncodedSize(t *testing.T) {
+	// Act
+	key, err := NewLexKeyCap(1, "tenant", 42)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Encode("tenant", 42), key)
+	assert.Equal(t, len(key), cap(key))
+}
+
+func TestShouldErrorWhenNewLexKeyCapReceivesNoParts(t *testing.T) {
+	// Act
+	_, err := NewLexKeyCap(16)
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestShouldMatchNewLexKeyWhenAppendingParts(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name  string
+		parts []any
+	}{
+		{"single part", []any{"tenant"}},
+		{"mixed types", []any{"tenant", 42, true, 1.5, id}},
+		{"nil part in the middle", []any{"a", nil, "b"}},
+		{"empty string after first part", []any{"a", "", "b"}},
+		{"trailing end marker", []any{"a", struct{}{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			expected, err := NewLexKey(tt.parts...)
+			require.NoError(t, err)
+
+			// Act
+			key := Empty
+			for _, p := range tt.parts {
+				key, err = key.Append(p)
+				require.NoError(t, err)
+			}
+
+			// Assert
+			assert.Equal(t, expected, key)
+		})
+	}
+}
+
+func TestShouldChainAppendCalls(t *testing.T) {
+	// Act
+	key, err := Encode("tenant").Append("users")
+	require.NoError(t, err)
+	key, err = key.Append(42)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, Encode("tenant", "users", 42), key)
+}
+
+func TestShouldNotAliasReceiverWhenAppending(t *testing.T) {
+	// Arrange
+	base := make(LexKey, 1, 16)
+	base[0] = 'a'
+
+	// Act
+	first, err := base.Append("b")
+	require.NoError(t, err)
+	second, err := base.Append("c")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, Encode("a", "b"), first)
+	assert.Equal(t, Encode("a", "c"), second)
+	assert.Equal(t, LexKey("a"), base)
+}
+
+func TestShouldErrorWhenAppendingUnsupportedPart(t *testing.T) {
+	// Act
+	_, err := Encode("a").Append(map[string]int{})
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestShouldReturnSmallestGreaterKeyFromNext(t *testing.T) {
+	// Arrange
+	key := Encode("a", "b")
+
+	// Act
+	next := key.Next()
+
+	// Assert
+	test.AssertHexEqual(t, "61006200", next)
+	assert.Positive(t, Compare(next, key))
+	assert.Negative(t, Compare(next, Encode("a", "b", nil)))
+	assert.Equal(t, Encode("a", "b"), key)
+}
+
+func TestShouldReturnPredecessorFromPrev(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      LexKey
+		expected LexKey
+	}{
+		{"trailing zero is stripped", LexKey{0x61, 0x00}, LexKey{0x61}},
+		{"single zero byte yields the empty key", LexKey{0x00}, LexKey{}},
+		{"last byte is decremented", LexKey{0x61, 0x62}, LexKey{0x61, 0x61}},
+		{"end marker is decremented", LexKey{0x61, 0xff}, LexKey{0x61, 0xfe}},
+		{"one zero byte is stripped at a time", LexKey{0x61, 0x00, 0x00}, LexKey{0x61, 0x00}},
+		{"zero bytes before the last byte are kept", LexKey{0x01, 0x00, 0x00, 0x01}, LexKey{0x01, 0x00, 0x00, 0x00}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			prev, ok := tt.key.Prev()
+
+			// Assert
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, prev)
+			assert.Negative(t, Compare(prev, tt.key))
+		})
+	}
+}
+
+func TestShouldWalkAllZeroKeyDownToEmptyWithPrev(t *testing.T) {
+	// Arrange
+	key := LexKey{0x00, 0x00, 0x00}
+
+	// Act
+	steps := 0
+	for {
+		prev, ok := key.Prev()
+		if !ok {
+			break
+		}
+		key = prev
+		steps++
+	}
+
+	// Assert
+	assert.Equal(t, 3, steps)
+	assert.True(t, key.IsEmpty())
+}
+
+func TestShouldReportNoPredecessorForEmptyKey(t *testing.T) {
+	// Act
+	_, ok := LexKey{}.Prev()
+
+	// Assert
+	assert.False(t, ok)
+}
+
+func TestShouldUndoNextWithPrev(t *testing.T) {
+	// Arrange
+	key := Encode("tenant", 42)
+
+	// Act
+	prev, ok := key.Next().Prev()
+
+	// Assert
+	require.True(t, ok)
+	assert.Equal(t, key, prev)
+}
+
+func TestShouldShareBackingArrayWithBytes(t *testing.T) {
+	// Arrange
+	key := Encode("abc")
+
+	// Act
+	b := key.Bytes()
+	b[0] = 'z'
+
+	// Assert
+	assert.Equal(t, []byte("zbc"), b)
+	assert.Equal(t, LexKey("zbc"), key)
+}
+
+func TestShouldCopyInputInFromBytes(t *testing.T) {
+	// Arrange
+	buf := []byte("abc")
+
+	// Act
+	key := FromBytes(buf)
+	buf[0] = 'z'
+
+	// Assert
+	assert.Equal(t, Encode("abc"), key)
+}
+
+func TestShouldReturnEmptyNonNilKeyFromNilBytes(t *testing.T) {
+	// Act
+	key := FromBytes(nil)
+
+	// Assert
+	assert.NotNil(t, key)
+	assert.True(t, key.IsEmpty())
+}
+
+func TestShouldPinInfinityBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{"positive infinity", math.Inf(1), "fff0000000000000"},
+		{"negative infinity", math.Inf(-1), "000fffffffffffff"},
+		{"float32 positive infinity", float32(math.Inf(1)), "fff0000000000000"},
+		{"float32 negative infinity", float32(math.Inf(-1)), "000fffffffffffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act / Assert
+			test.AssertHexEqual(t, tt.expected, Encode(tt.value))
+		})
+	}
+}
+
+func TestShouldSortInfinitiesOutsideFiniteFloats(t *testing.T) {
+	// Arrange
+	values := []float64{
+		math.Inf(-1), -math.MaxFloat64, -1, -math.SmallestNonzeroFloat64, 0,
+		math.SmallestNonzeroFloat64, 1, math.MaxFloat64, math.Inf(1),
+	}
+
+	// Act / Assert
+	for i := 1; i < len(values); i++ {
+		assert.Negative(t, Compare(Encode(values[i-1]), Encode(values[i])), "%v should sort before %v", values[i-1], values[i])
+	}
+}
+
+func TestShouldKeepOrderedNaNsOutsideInfinities(t *testing.T) {
+	// Arrange
+	first := &Encoder{Separator: Separator, EndMarker: EndMarker, NaN: NaNFirst}
+	last := &Encoder{Separator: Separator, EndMarker: EndMarker, NaN: NaNLast}
+
+	// Act / Assert
+	assert.Negative(t, Compare(first.Encode(math.NaN()), Encode(math.Inf(-1))))
+	assert.Positive(t, Compare(last.Encode(math.NaN()), Encode(math.Inf(1))))
+}
+
+func TestShouldParseLenientHex(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected LexKey
+	}{
+		{"0x prefix", "0x68656c6c6f", LexKey("hello")},
+		{"0X prefix", "0X68656C", LexKey("hel")},
+		{"spaces between bytes", "68 65 6c", LexKey("hel")},
+		{"mixed case", "68656C6c6F", LexKey("hello")},
+		{"surrounding whitespace and newlines", "  0x68\t65\n6c  ", LexKey("hel")},
+		{"plain", "6869", LexKey("hi")},
+		{"empty", "", LexKey{}},
+		{"prefix only", "0x", LexKey{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			key, err := ParseHex(tt.input)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, key)
+		})
+	}
+}
+
+func TestShouldRejectInvalidLenientHex(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"odd length", "0x686"},
+		{"invalid characters", "0xzz"},
+		{"prefix in the middle", "68 0x65"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := ParseHex(tt.input)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidHex)
+		})
+	}
+}
+
+func TestShouldKeepFromHexStringStrict(t *testing.T) {
+	// Arrange
+	var key LexKey
+
+	// Act / Assert
+	require.Error(t, key.FromHexString("0x6869"))
+	require.Error(t, key.FromHexString("68 69"))
+}
+
+func TestShouldCompareKeysIgnoringBoundaryMarkers(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     LexKey
+		expected bool
+	}{
+		{"same key without markers", Encode("a", 1), Encode("a", 1), true},
+		{"end marker", Encode("tenant"), EncodeLast("tenant"), true},
+		{"separator", EncodeFirst("tenant"), Encode("tenant"), true},
+		{"different markers", EncodeFirst("tenant"), EncodeLast("tenant"), true},
+		{"different keys", Encode("tenant"), EncodeLast("tenants"), false},
+		{"only one marker is stripped", Encode("tenant"), append(EncodeLast("tenant"), EndMarker), false},
+		{"empty keys", LexKey{}, LexKey{EndMarker}, true},
+		{"ambiguous empty last part", Encode("a", ""), Encode("a"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act / Assert
+			assert.Equal(t, tt.expected, EqualIgnoringBoundary(tt.a, tt.b))
+		})
+	}
+}
+
+func TestShouldExtractLeadingPartsWithPrefixParts(t *testing.T) {
+	// Arrange
+	key := Encode("tenant", "user", "order")
+	tests := []struct {
+		name     string
+		n        int
+		expected LexKey
+	}{
+		{"one part", 1, Encode("tenant")},
+		{"two parts", 2, Encode("tenant", "user")},
+		{"all parts", 3, key},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			prefix, err := key.PrefixParts(tt.n)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, prefix)
+			assert.Equal(t, key[:len(prefix)], prefix)
+		})
+	}
+}
+
+func TestShouldRejectInvalidPartCountInPrefixParts(t *testing.T) {
+	// Arrange
+	key := Encode("tenant", "user", "order")
+
+	// Act
+	_, errTooMany := key.PrefixParts(4)
+	_, errZero := key.PrefixParts(0)
+
+	// Assert
+	require.Error(t, errTooMany)
+	assert.Contains(t, errTooMany.Error(), "fewer than 4")
+	require.Error(t, errZero)
+}
+
+func TestShouldAppendSeparatorWithWithSeparator(t *testing.T) {
+	// Arrange
+	partition := Encode("p")
+	row := Encode("r", 1)
+	pk := NewPrimaryKey(partition, row)
+
+	// Act
+	prefix := partition.WithSeparator()
+
+	// Assert
+	assert.Equal(t, pk.PartitionPrefix(), prefix)
+	assert.Equal(t, pk.Encode(), append(prefix, row...))
+	assert.Equal(t, LexKey("p"), partition, "input must not be modified")
+	assert.Equal(t, LexKey{Separator}, LexKey(nil).WithSeparator())
+}
+
+func TestShouldEncodeSingleLikeNewLexKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{"string", "user"},
+		{"int", 42},
+		{"uint8", uint8(7)},
+		{"float32", float32(1.5)},
+		{"uuid", uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")},
+		{"time", time.Unix(1700000000, 0)},
+		{"nil", nil},
+		{"named type", testStatus(3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			expected, err := NewLexKey(tt.value)
+			require.NoError(t, err)
+
+			// Act
+			key, err := EncodeSingle(tt.value)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, expected, key)
+		})
+	}
+}
+
+func TestShouldRejectMultiplePartsInEncodeSingle(t *testing.T) {
+	// Act
+	_, err := EncodeSingle([]any{"a", 1})
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "got 2 parts")
+}
+
+func TestShouldRejectUnsupportedTypeInEncodeSingle(t *testing.T) {
+	// Act
+	_, err := EncodeSingle(map[string]int{})
+
+	// Assert
+	require.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+func TestShouldMatchEncodedLengthWithEncodedSize(t *testing.T) {
+	id := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	n := 7
+	tests := []struct {
+		name  string
+		parts []any
+	}{
+		{"string", []any{"hello"}},
+		{"narrow integers", []any{int8(1), int16(2), int32(3), uint8(4), uint16(5), uint32(6)}},
+		{"mixed types", []any{"key", id, -99, 4.2, true}},
+		{"times", []any{time.Unix(1700000000, 0), time.Duration(42), ZonedTime(time.Unix(0, 0))}},
+		{"nil and end marker", []any{"a", nil, struct{}{}}},
+		{"variable width", []any{[]byte("data"), LexKey{1, 2}, LengthPrefixed("xyz"), ""}},
+		{"decimal", []any{Decimal{Coefficient: -150, Exponent: -2}}},
+		{"named, pointer and array", []any{testStatus(2), &n, [4]byte{1, 2, 3, 4}}},
+		{"float16 and json number", []any{Float16(0x3C00), json.Number("12")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			size, err := EncodedSize(tt.parts...)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, len(Encode(tt.parts...)), size)
+		})
+	}
+}
+
+func TestShouldErrorFromEncodedSizeForInvalidParts(t *testing.T) {
+	// Act
+	_, errEmpty := EncodedSize()
+	_, errUnsupported := EncodedSize("a", map[string]int{})
+
+	// Assert
+	require.ErrorIs(t, errEmpty, ErrEmptyKey)
+	require.ErrorIs(t, errUnsupported, ErrUnsupportedType)
+}
+
+func TestShouldIncludeTypeTagsInEncoderEncodedSize(t *testing.T) {
+	// Arrange
+	enc := DefaultEncoder()
+	enc.TypeTags = true
+	parts := []any{"a", nil, 42, struct{}{}}
+
+	// Act
+	size, err := enc.EncodedSize(parts...)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, len(enc.Encode(parts...)), size)
+}
+
+func TestShouldTruncateKeyToMaxLength(t *testing.T) {
+	// Arrange
+	key := Encode("user", int64(42))
+	tests := []struct {
+		name     string
+		maxLen   int
+		expected string
+	}{
+		{"at segment boundary", 4, "75736572"},
+		{"keeping separator", 5, "7573657200"},
+		{"inside fixed-width field", 8, "7573657200800000"},
+		{"longer than key", 100, "7573657200800000000000002a"},
+		{"zero", 0, ""},
+		{"negative", -1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			truncated := key.Truncate(tt.maxLen)
+
+			// Assert
+			test.AssertHexEqual(t, tt.expected, truncated)
+			assert.LessOrEqual(t, Compare(truncated, key), 0)
+		})
+	}
+}
+
+func TestShouldNotOverwriteOriginalWhenAppendingToTruncatedKey(t *testing.T) {
+	// Arrange
+	key := Encode("user", int64(42))
+	original := append(LexKey{}, key...)
+
+	// Act
+	_ = append(key.Truncate(4), 0xAA)
+
+	// Assert
+	assert.Equal(t, original, key)
+}
+
+func TestShouldAppendKeyBytesWithAppendTo(t *testing.T) {
+	// Arrange
+	key := Encode("user", int64(42))
+	buf := make([]byte, 0, 64)
+	buf = append(buf, 0xAA)
+
+	// Act
+	buf = key.AppendTo(buf)
+	buf = Encode("x").AppendTo(buf)
+
+	// Assert
+	assert.Equal(t, byte(0xAA), buf[0])
+	assert.Equal(t, []byte(key), buf[1:1+len(key)])
+	assert.Equal(t, []byte("x"), buf[1+len(key):])
+	assert.Equal(t, []byte(key), key.AppendTo(nil))
+}
+
+func TestShouldHashEqualKeysEqually(t *testing.T) {
+	// Arrange
+	a := Encode("user", int64(42))
+	b := FromBytes(a)
+
+	// Act / Assert
+	assert.Equal(t, a.Hash(), b.Hash())
+	assert.Equal(t, LexKey(nil).Hash(), Empty.Hash())
+	assert.NotEqual(t, a.Hash(), Encode("user", int64(43)).Hash())
+}
+
+func TestShouldMatchStandardFNV1aInHash(t *testing.T) {
+	// Arrange
+	key := Encode("tenant", "orders", int64(7))
+	h := fnv.New64a()
+	_, _ = h.Write(key)
+
+	// Act / Assert
+	assert.Equal(t, h.Sum64(), key.Hash())
+	assert.Equal(t, uint64(0xcbf29ce484222325), Empty.Hash(), "FNV-1a offset basis")
+}
+
+func TestShouldSpreadSequentialKeysAcrossShardsWithHash(t *testing.T) {
+	// Arrange
+	const shards, keys = 8, 8000
+	counts := make([]int, shards)
+
+	// Act
+	for i := 0; i < keys; i++ {
+		counts[Encode("user", int64(i)).Hash()%shards]++
+	}
+
+	// Assert: every shard within 20% of the even share
+	for shard, n := range counts {
+		assert.InDelta(t, keys/shards, n, keys/shards/5, "shard %d", shard)
+	}
+}
+
+func TestShouldComputeExclusiveUpperBound(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   LexKey
+		expected string
+	}{
+		{"no trailing 0xFF", LexKey("ab"), "6163"},
+		{"one trailing 0xFF", LexKey("a\xff"), "62"},
+		{"several trailing 0xFF", LexKey("a\xfe\xff\xff"), "61ff"},
+		{"encoded prefix", Encode("a"), "62"},
+		{"trailing separator", Encode("a").WithSeparator(), "6101"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			upper, ok := ExclusiveUpperBound(tt.prefix)
+
+			// Assert
+			require.True(t, ok)
+			test.AssertHexEqual(t, tt.expected, upper)
+		})
+	}
+}
+
+func TestShouldReportNoExclusiveUpperBoundForAllEndMarkerPrefix(t *testing.T) {
+	for _, prefix := range []LexKey{nil, Empty, {EndMarker}, {EndMarker, EndMarker, EndMarker}} {
+		// Act
+		upper, ok := ExclusiveUpperBound(prefix)
+
+		// Assert
+		assert.False(t, ok, "prefix %x", prefix)
+		assert.Nil(t, upper)
+	}
+}
+
+func TestShouldBoundEveryByteExtensionWithExclusiveUpperBound(t *testing.T) {
+	// Arrange: EncodeLast misses raw extensions of a prefix ending in 0xFF
+	prefix := LexKey("a\xff")
+	upper, ok := ExclusiveUpperBound(prefix)
+	require.True(t, ok)
+	inside := []LexKey{prefix, LexKey("a\xff\x00"), LexKey("a\xff\xff"), LexKey("a\xff\xff\x01")}
+	outside := []LexKey{LexKey("a\xfe\xff"), LexKey("b"), LexKey("b\x00")}
+
+	// Act / Assert
+	assert.False(t, Bounds{Lower: prefix, Upper: EncodeLast(prefix)}.Contains(LexKey("a\xff\xff\x01")))
+	for _, key := range inside {
+		assert.True(t, Bounds{Lower: prefix, Upper: upper}.Contains(key), "key %x", key)
+	}
+	for _, key := range outside {
+		assert.False(t, Bounds{Lower: prefix, Upper: upper}.Contains(key), "key %x", key)
+	}
+}
+
+func TestShouldNotAllocateInPutLexInt(t *testing.T) {
+	// Arrange
+	var buf [8]byte
+
+	// Act
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = putLexInt64(buf[:], -1<<40)
+		_ = putLexInt32(buf[:], -1<<20)
+		_ = putLexInt16(buf[:], -1<<10)
+	})
+
+	// Assert
+	assert.Zero(t, allocs)
+}
@@ -0,0 +1,111 @@
+package lexkey
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// keyFields returns the indexes of t's fields tagged `lexkey:"N"`, ordered by N. Tags must
+// number the parts 0..n-1 without gaps or repeats, and tagged fields must be exported.
+// Untagged fields and fields tagged `lexkey:"-"` are ignored.
+func keyFields(t reflect.Type) ([]int, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%v is not a struct", t)
+	}
+	byPos := make(map[int]int)
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("lexkey")
+		if !ok || tag == "-" {
+			continue
+		}
+		pos, err := strconv.Atoi(tag)
+		if err != nil || pos < 0 {
+			return nil, fmt.Errorf("field %s: invalid lexkey tag %q", f.Name, tag)
+		}
+		if !f.IsExported() {
+			return nil, fmt.Errorf("field %s: tagged field must be exported", f.Name)
+		}
+		if prev, dup := byPos[pos]; dup {
+			return nil, fmt.Errorf("fields %s and %s: duplicate lexkey tag %d", t.Field(prev).Name, f.Name, pos)
+		}
+		byPos[pos] = i
+	}
+	if len(byPos) == 0 {
+		return nil, fmt.Errorf("%v has no lexkey-tagged fields", t)
+	}
+	indexes := make([]int, len(byPos))
+	for pos := range indexes {
+		i, ok := byPos[pos]
+		if !ok {
+			return nil, fmt.Errorf("missing lexkey tag %d in %v", pos, t)
+		}
+		indexes[pos] = i
+	}
+	return indexes, nil
+}
+
+// Marshal encodes the lexkey-tagged fields of the struct v (or a pointer to one) as a
+// composite key in tag order, like NewLexKey with the field values listed by position; see
+// Unmarshal for the tag format. Returns an error if v is not a struct, the tags are invalid,
+// or a field cannot be encoded.
+func Marshal(v any) (LexKey, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return LexKey([]byte{}), errors.New("cannot marshal LexKey: nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return LexKey([]byte{}), errors.New("cannot marshal LexKey: v must be a struct")
+	}
+	indexes, err := keyFields(rv.Type())
+	if err != nil {
+		return LexKey([]byte{}), fmt.Errorf("cannot marshal LexKey: %w", err)
+	}
+	parts := make([]any, len(indexes))
+	for pos, i := range indexes {
+		parts[pos] = rv.Field(i).Interface()
+	}
+	return NewLexKey(parts...)
+}
+
+// Unmarshal decodes key into the struct pointed to by v, mirroring encoding/json for keys.
+// The struct's exported fields are tagged with their part position, and their types form
+// the Decode schema:
+//
+//	type OrderKey struct {
+//		Tenant string `lexkey:"0"`
+//		ID     int64  `lexkey:"1"`
+//		Open   bool   `lexkey:"2"`
+//	}
+//
+// Returns an error if v is not a non-nil pointer to a struct, the tags are invalid, or the
+// key does not decode with the field types. Fields are only assigned when the whole key
+// decodes, so v is unchanged on error.
+func Unmarshal(key LexKey, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("cannot unmarshal LexKey: v must be a non-nil pointer to a struct")
+	}
+	target := rv.Elem()
+	indexes, err := keyFields(target.Type())
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal LexKey: %w", err)
+	}
+	schema := make([]reflect.Type, len(indexes))
+	for pos, i := range indexes {
+		schema[pos] = target.Type().Field(i).Type
+	}
+	values, err := Decode(key, schema...)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal LexKey into %v: %w", target.Type(), err)
+	}
+	for pos, i := range indexes {
+		target.Field(i).Set(reflect.ValueOf(values[pos]))
+	}
+	return nil
+}
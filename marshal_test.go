@@ -0,0 +1,152 @@
+package lexkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testOrderKey struct {
+	Open   bool   `lexkey:"2"`
+	Tenant string `lexkey:"0"`
+	ID     int64  `lexkey:"1"`
+	Note   string
+}
+
+func TestShouldUnmarshalKeyIntoTaggedStruct(t *testing.T) {
+	// Arrange
+	key := Encode("acme", int64(42), true)
+	var got testOrderKey
+
+	// Act
+	err := Unmarshal(key, &got)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, testOrderKey{Tenant: "acme", ID: 42, Open: true}, got)
+}
+
+func TestShouldUnmarshalNamedFieldTypes(t *testing.T) {
+	// Arrange
+	type statusKey struct {
+		Name   testName   `lexkey:"0"`
+		Status testStatus `lexkey:"1"`
+	}
+	var got statusKey
+
+	// Act
+	err := Unmarshal(Encode(testName("bob"), testStatus(3)), &got)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, statusKey{Name: "bob", Status: 3}, got)
+}
+
+func TestShouldErrorWhenKeyDoesNotMatchStructFields(t *testing.T) {
+	// Arrange: the second part is a string, not an int64
+	key := Encode("acme", "forty-two", true)
+	original := testOrderKey{Note: "kept"}
+	got := original
+
+	// Act
+	err := Unmarshal(key, &got)
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot unmarshal LexKey into lexkey.testOrderKey")
+	assert.Equal(t, original, got, "target must be unchanged on error")
+}
+
+func TestShouldRejectInvalidUnmarshalTargets(t *testing.T) {
+	key := Encode("a")
+	tests := []struct {
+		name   string
+		target any
+	}{
+		{"non-pointer", testOrderKey{}},
+		{"nil pointer", (*testOrderKey)(nil)},
+		{"pointer to non-struct", new(string)},
+		{"no tagged fields", &struct{ A string }{}},
+		{"gap in tags", &struct {
+			A string `lexkey:"0"`
+			B string `lexkey:"2"`
+		}{}},
+		{"duplicate tags", &struct {
+			A string `lexkey:"0"`
+			B string `lexkey:"0"`
+		}{}},
+		{"invalid tag", &struct {
+			A string `lexkey:"first"`
+		}{}},
+		{"unexported tagged field", &struct {
+			a string `lexkey:"0"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := Unmarshal(key, tt.target)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestShouldMarshalTaggedStructInTagOrder(t *testing.T) {
+	// Arrange
+	order := testOrderKey{Tenant: "acme", ID: 42, Open: true, Note: "not part of the key"}
+	expected, err := NewLexKey("acme", int64(42), true)
+	require.NoError(t, err)
+
+	// Act
+	key, err := Marshal(order)
+	keyFromPointer, errPointer := Marshal(&order)
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, errPointer)
+	assert.Equal(t, expected, key)
+	assert.Equal(t, expected, keyFromPointer)
+}
+
+func TestShouldRoundTripStructThroughMarshalAndUnmarshal(t *testing.T) {
+	// Arrange
+	original := testOrderKey{Tenant: "acme", ID: -7, Open: false}
+
+	// Act
+	key, err := Marshal(original)
+	require.NoError(t, err)
+	var decoded testOrderKey
+	err = Unmarshal(key, &decoded)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, original, decoded)
+}
+
+func TestShouldRejectInvalidMarshalInputs(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{"nil", nil},
+		{"nil pointer", (*testOrderKey)(nil)},
+		{"non-struct", "acme"},
+		{"unsupported field type", struct {
+			A map[string]int `lexkey:"0"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := Marshal(tt.value)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
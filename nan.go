@@ -0,0 +1,65 @@
+package lexkey
+
+import "errors"
+
+// NaNPolicy controls how NaN float values are encoded by an Encoder.
+type NaNPolicy uint8
+
+const (
+	// NaNCanonical encodes every NaN as a fixed legacy bit pattern (float64 0x7FF8000000000001,
+	// float32 0x7FC00001, Float16 0x7E01). The pattern is written without the ordering transform,
+	// so NaN sorts among the tiniest negative values rather than at either end. This is the
+	// default to keep existing keys stable; prefer NaNLast or NaNFirst for new data.
+	NaNCanonical NaNPolicy = iota
+	// NaNLast encodes NaN above +Inf, so it sorts after every other value.
+	NaNLast
+	// NaNFirst encodes NaN below -Inf, so it sorts before every other value.
+	NaNFirst
+	// NaNError rejects NaN values with an error.
+	NaNError
+)
+
+// errNaN is returned when encoding NaN under the NaNError policy.
+var errNaN = errors.New("NaN is not allowed by the encoder's NaN policy")
+
+// nanBits64 returns the encoded float64 bits for NaN under the policy.
+func (p NaNPolicy) nanBits64() (uint64, error) {
+	switch p {
+	case NaNLast:
+		return 0xFFF8000000000000, nil // transformed +quiet NaN, above +Inf (0xFFF0...)
+	case NaNFirst:
+		return 0x0007FFFFFFFFFFFF, nil // transformed -quiet NaN, below -Inf (0x000F...)
+	case NaNError:
+		return 0, errNaN
+	default:
+		return 0x7FF8000000000001, nil
+	}
+}
+
+// nanBits32 returns the encoded float32 bits for NaN under the policy.
+func (p NaNPolicy) nanBits32() (uint32, error) {
+	switch p {
+	case NaNLast:
+		return 0xFFC00000, nil
+	case NaNFirst:
+		return 0x003FFFFF, nil
+	case NaNError:
+		return 0, errNaN
+	default:
+		return 0x7FC00001, nil
+	}
+}
+
+// nanBits16 returns the encoded Float16 bits for NaN under the policy.
+func (p NaNPolicy) nanBits16() (uint16, error) {
+	switch p {
+	case NaNLast:
+		return 0xFE00, nil
+	case NaNFirst:
+		return 0x01FF, nil
+	case NaNError:
+		return 0, errNaN
+	default:
+		return float16CanonicalNaN, nil
+	}
+}
@@ -0,0 +1,132 @@
+package lexkey
+
+import (
+	"math"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldEncodeNaNPerPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    NaNPolicy
+		expect64  string
+		expect32  string
+		expectErr bool
+	}{
+		{"canonical", NaNCanonical, "7ff8000000000001", "7fc00001", false},
+		{"last", NaNLast, "fff8000000000000", "ffc00000", false},
+		{"first", NaNFirst, "0007ffffffffffff", "003fffff", false},
+		{"error", NaNError, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			enc := DefaultEncoder()
+			enc.NaN = tt.policy
+			buf := make([]byte, 8)
+
+			// Act
+			key64, err64 := enc.NewLexKey(math.NaN())
+			n32, err32 := enc.encodePart(buf, float32(math.NaN()))
+
+			// Assert
+			if tt.expectErr {
+				require.Error(t, err64)
+				require.Error(t, err32)
+				return
+			}
+			require.NoError(t, err64)
+			require.NoError(t, err32)
+			test.AssertHexEqual(t, tt.expect64, key64)
+			test.AssertHexEqual(t, tt.expect32, buf[:n32])
+		})
+	}
+}
+
+func TestShouldOrderNaNAroundInfinitiesPerPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy NaNPolicy
+		first  bool
+	}{
+		{"last", NaNLast, false},
+		{"first", NaNFirst, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			enc := DefaultEncoder()
+			enc.NaN = tt.policy
+			buf32 := make([]byte, 4)
+			inf32 := make([]byte, 4)
+			negInf32 := make([]byte, 4)
+
+			// Act
+			nan := enc.Encode(math.NaN())
+			inf := enc.Encode(math.Inf(1))
+			negInf := enc.Encode(math.Inf(-1))
+			_, _ = enc.encodePart(buf32, float32(math.NaN()))
+			_, _ = enc.encodePart(inf32, float32(math.Inf(1)))
+			_, _ = enc.encodePart(negInf32, float32(math.Inf(-1)))
+
+			// Assert
+			if tt.first {
+				assert.Less(t, Compare(nan, negInf), 0)
+				assert.Less(t, Compare(buf32, negInf32), 0)
+			} else {
+				assert.Greater(t, Compare(nan, inf), 0)
+				assert.Greater(t, Compare(buf32, inf32), 0)
+			}
+		})
+	}
+}
+
+func TestShouldEncodeFloat16NaNPerPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   NaNPolicy
+		expected string
+	}{
+		{"canonical", NaNCanonical, "7e01"},
+		{"last", NaNLast, "fe00"},
+		{"first", NaNFirst, "01ff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			enc := DefaultEncoder()
+			enc.NaN = tt.policy
+
+			// Act
+			key := enc.Encode(Float16(0x7E00))
+
+			// Assert
+			test.AssertHexEqual(t, tt.expected, key)
+		})
+	}
+
+	enc := DefaultEncoder()
+	enc.NaN = NaNError
+	_, err := enc.NewLexKey(Float16(0x7E00))
+	require.Error(t, err)
+}
+
+func TestShouldKeepNonNaNFloatsUnaffectedByPolicy(t *testing.T) {
+	// Arrange
+	enc := DefaultEncoder()
+	enc.NaN = NaNError
+
+	// Act
+	key, err := enc.NewLexKey(3.14, float32(-1))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Encode(3.14, float32(-1)), key)
+}
@@ -0,0 +1,25 @@
+package lexkey
+
+import (
+	"fmt"
+	"math"
+)
+
+// NonNeg marks a signed integer known to be non-negative (a count, an offset) to be encoded
+// with the uint64 encoding instead of the int64 one, so it matches fields stored as unsigned:
+// Encode(NonNeg(5)) equals Encode(uint64(5)). Encoding a negative NonNeg is an error.
+// NonNeg schema entries decode from the uint64 encoding.
+type NonNeg int64
+
+// errNegativeNonNeg reports a NonNeg that failed its non-negative contract.
+func errNegativeNonNeg(v NonNeg) error {
+	return fmt.Errorf("NonNeg value %d is negative", int64(v))
+}
+
+// decodeNonNeg decodes a NonNeg from its 8-byte uint64 encoding.
+func decodeNonNeg(u uint64) (NonNeg, error) {
+	if u > math.MaxInt64 {
+		return 0, fmt.Errorf("value %d overflows NonNeg", u)
+	}
+	return NonNeg(u), nil
+}
@@ -0,0 +1,53 @@
+package lexkey
+
+import (
+	"math"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldEncodeNonNegLikeUint64(t *testing.T) {
+	tests := []struct {
+		name  string
+		value int64
+	}{
+		{"zero", 0},
+		{"small", 42},
+		{"max", math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			key, err := NewLexKey("rows", NonNeg(tt.value))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, Encode("rows", uint64(tt.value)), key)
+		})
+	}
+	test.AssertHexEqual(t, "000000000000002a", Encode(NonNeg(42)))
+}
+
+func TestShouldRejectNegativeNonNeg(t *testing.T) {
+	// Act
+	_, err := NewLexKey("rows", NonNeg(-1))
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "NonNeg value -1 is negative")
+}
+
+func TestShouldDecodeNonNegFromUint64Encoding(t *testing.T) {
+	// Act
+	values, err := Decode(Encode(NonNeg(7)), nonNegType)
+	_, errOverflow := Decode(Encode(uint64(math.MaxUint64)), nonNegType)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{NonNeg(7)}, values)
+	require.Error(t, errOverflow)
+}
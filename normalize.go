@@ -0,0 +1,12 @@
+package lexkey
+
+import "golang.org/x/text/unicode/norm"
+
+// EncodeNormalized encodes s as a string part after Unicode NFC normalization, so canonically
+// equivalent strings, such as precomposed "é" (U+00E9) and "e" followed by a combining acute
+// accent (U+0065 U+0301), produce identical keys. The normalized form is what gets stored.
+// Compatibility equivalents (e.g. "ﬁ" and "fi") stay distinct; combine with CaseFold-style
+// handling in the caller if case-insensitive lookups are also needed.
+func EncodeNormalized(s string) LexKey {
+	return Encode(norm.NFC.String(s))
+}
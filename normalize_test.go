@@ -0,0 +1,29 @@
+package lexkey
+
+import (
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldEncodeCanonicallyEquivalentStringsIdentically(t *testing.T) {
+	// Arrange
+	precomposed := "caf\u00e9"
+	decomposed := "cafe\u0301"
+
+	// Act
+	a := EncodeNormalized(precomposed)
+	b := EncodeNormalized(decomposed)
+
+	// Assert
+	assert.NotEqual(t, Encode(precomposed), Encode(decomposed))
+	assert.Equal(t, a, b)
+	test.AssertHexEqual(t, "636166c3a9", a)
+}
+
+func TestShouldKeepCompatibilityEquivalentsDistinctWhenNormalizing(t *testing.T) {
+	// Assert
+	assert.NotEqual(t, EncodeNormalized("\ufb01"), EncodeNormalized("fi"))
+	assert.Equal(t, Encode("plain ascii"), EncodeNormalized("plain ascii"))
+}
@@ -0,0 +1,79 @@
+package lexkey
+
+import (
+	"fmt"
+	"math"
+)
+
+// NumberedField is a key part tagged with a stable field number, in the style of protobuf
+// field numbers, for keys that must survive schema changes; see EncodeNumbered.
+type NumberedField struct {
+	Number int
+	Value  any
+}
+
+// EncodeNumbered encodes fields as self-describing segments, each the field number and the
+// value's byte length as sortable uvarints (see EncodeUvarintSortable), then the value encoded
+// like a single NewLexKey part. Segments carry their own boundaries, so fields can be added
+// or removed later without shifting how the other fields decode; see DecodeNumbered.
+//
+// Field numbers must be in 0..math.MaxInt32, the range DecodeNumbered accepts, and strictly
+// increasing. Ordering: keys compare by the
+// first field number, then that value's length, then its bytes, then the next field. So
+// fixed-width values (integers, floats, times, UUIDs) sort naturally, but strings and bytes
+// sort shortest first, and a key with an extra field sorts after the same key without it.
+func EncodeNumbered(fields ...NumberedField) (LexKey, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("cannot create LexKey: %w", ErrEmptyKey)
+	}
+	var out LexKey
+	prev := -1
+	for _, f := range fields {
+		if f.Number <= prev {
+			return nil, fmt.Errorf("cannot encode field %d: field numbers must be non-negative and strictly increasing", f.Number)
+		}
+		if f.Number > math.MaxInt32 {
+			return nil, fmt.Errorf("cannot encode field %d: field number exceeds %d", f.Number, math.MaxInt32)
+		}
+		prev = f.Number
+		value, err := encodeToBytes(f.Value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode field %d (%T): %w", f.Number, f.Value, err)
+		}
+		out = append(out, EncodeUvarintSortable(uint64(f.Number))...)
+		out = append(out, EncodeUvarintSortable(uint64(len(value)))...)
+		out = append(out, value...)
+	}
+	return out, nil
+}
+
+// DecodeNumbered splits a key produced by EncodeNumbered into its encoded values by field
+// number. Decode each value with Decode(LexKey(value), type); fields unknown to the reader
+// can simply be ignored. The values are copies and do not alias key.
+func DecodeNumbered(key LexKey) (map[int][]byte, error) {
+	fields := make(map[int][]byte)
+	rest := []byte(key)
+	prev := -1
+	for len(rest) > 0 {
+		number, n, err := DecodeUvarintSortable(rest)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode field number: %w", err)
+		}
+		if number > math.MaxInt32 || int(number) <= prev {
+			return nil, fmt.Errorf("cannot decode field number %d: out of order or too large", number)
+		}
+		rest = rest[n:]
+		length, n, err := DecodeUvarintSortable(rest)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode field %d length: %w", number, err)
+		}
+		rest = rest[n:]
+		if length > uint64(len(rest)) {
+			return nil, fmt.Errorf("cannot decode field %d: value length %d exceeds remaining %d bytes", number, length, len(rest))
+		}
+		prev = int(number)
+		fields[prev] = append([]byte{}, rest[:length]...)
+		rest = rest[length:]
+	}
+	return fields, nil
+}
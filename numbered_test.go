@@ -0,0 +1,132 @@
+package lexkey
+
+import (
+	"math"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldEncodeNumberedFields(t *testing.T) {
+	// Act
+	key, err := EncodeNumbered(NumberedField{1, "ab"}, NumberedField{2, true})
+
+	// Assert
+	require.NoError(t, err)
+	test.AssertHexEqual(t, "01026162020101", key)
+}
+
+func TestShouldDecodeOldFieldsAfterNewFieldIsAdded(t *testing.T) {
+	// Arrange: v2 of the schema added field 3 between existing fields and field 9 at the end
+	v2, err := EncodeNumbered(
+		NumberedField{1, "tenant"},
+		NumberedField{2, int64(42)},
+		NumberedField{3, "region"},
+		NumberedField{9, true},
+	)
+	require.NoError(t, err)
+
+	// Act: a v1 reader only knows fields 1 and 2
+	fields, err := DecodeNumbered(v2)
+	require.NoError(t, err)
+	values1, err := Decode(LexKey(fields[1]), stringType)
+	require.NoError(t, err)
+	values2, err := Decode(LexKey(fields[2]), int64Type)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []any{"tenant"}, values1)
+	assert.Equal(t, []any{int64(42)}, values2)
+	assert.Len(t, fields, 4)
+}
+
+func TestShouldDecodeNumberedValuesContainingZeroBytes(t *testing.T) {
+	// Arrange
+	key, err := EncodeNumbered(NumberedField{0, []byte{0x00, 0x00}}, NumberedField{300, int64(0)})
+	require.NoError(t, err)
+
+	// Act
+	fields, err := DecodeNumbered(key)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[int][]byte{0: {0x00, 0x00}, 300: Encode(int64(0))}, fields)
+}
+
+func TestShouldSortNumberedKeysByFirstFieldValue(t *testing.T) {
+	// Arrange
+	a, err := EncodeNumbered(NumberedField{1, int64(-5)}, NumberedField{2, "z"})
+	require.NoError(t, err)
+	b, err := EncodeNumbered(NumberedField{1, int64(7)}, NumberedField{2, "a"})
+	require.NoError(t, err)
+
+	// Act / Assert
+	assert.Negative(t, Compare(a, b))
+}
+
+func TestShouldRejectInvalidNumberedFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []NumberedField
+	}{
+		{"no fields", nil},
+		{"negative number", []NumberedField{{-1, "a"}}},
+		{"decreasing numbers", []NumberedField{{2, "a"}, {1, "b"}}},
+		{"repeated number", []NumberedField{{1, "a"}, {1, "b"}}},
+		{"unsupported value", []NumberedField{{1, map[string]int{}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := EncodeNumbered(tt.fields...)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestShouldRejectMalformedNumberedKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		key  LexKey
+	}{
+		{"missing length", LexKey{0x01}},
+		{"value exceeds key", LexKey{0x01, 0x05, 'a'}},
+		{"out of order fields", LexKey{0x02, 0x00, 0x01, 0x00}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := DecodeNumbered(tt.key)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestShouldBoundNumberedFieldNumbersByDecoderLimit(t *testing.T) {
+	// Arrange
+	key, err := EncodeNumbered(NumberedField{Number: math.MaxInt32, Value: "a"})
+	require.NoError(t, err)
+
+	// Act
+	fields, err := DecodeNumbered(key)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Encode("a"), LexKey(fields[math.MaxInt32]))
+	if math.MaxInt == math.MaxInt32 {
+		t.Skip("int cannot hold a field number above math.MaxInt32")
+	}
+	for _, n := range []int64{math.MaxInt32 + 1, 1 << 40} {
+		_, err := EncodeNumbered(NumberedField{Number: int(n), Value: "a"})
+		require.Error(t, err, "field number %d", n)
+		assert.Contains(t, err.Error(), "exceeds")
+	}
+}
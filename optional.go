@@ -0,0 +1,21 @@
+package lexkey
+
+// EncodeOptional encodes a tuple whose trailing fields may be absent. Trailing parts that
+// encode as nil (nil, nil pointers, invalid sql.Null values) are omitted instead of being
+// written as 0x00, so EncodeOptional("a", nil) equals Encode("a") and sorts before every
+// key that has the field, such as Encode("a", "b"). A nil part followed by a present one
+// keeps its position and still encodes as nil. If every part is absent the result is the
+// empty key.
+//
+// Keys rely on the prefix property: a key that is a byte prefix of another sorts first, so a
+// tuple with fewer trailing fields sorts before all tuples that extend it.
+func EncodeOptional(parts ...any) (LexKey, error) {
+	n := len(parts)
+	for n > 0 && canonicalizePart(parts[n-1]) == nil {
+		n--
+	}
+	if n == 0 {
+		return LexKey([]byte{}), nil
+	}
+	return NewLexKey(parts[:n]...)
+}
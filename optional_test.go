@@ -0,0 +1,59 @@
+package lexkey
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldSortShorterTupleBeforeExtensions(t *testing.T) {
+	// Arrange
+	short := Encode("a")
+	extensions := []LexKey{Encode("a", ""), Encode("a", "b"), Encode("a", int64(-1)), Encode("a", nil)}
+
+	// Act / Assert
+	for _, ext := range extensions {
+		assert.Negative(t, Compare(short, ext), "%x should sort before %x", []byte(short), []byte(ext))
+	}
+}
+
+func TestShouldOmitAbsentTrailingFields(t *testing.T) {
+	var missing *string
+	present := "b"
+	tests := []struct {
+		name     string
+		parts    []any
+		expected LexKey
+	}{
+		{"all present", []any{"a", "b"}, Encode("a", "b")},
+		{"trailing nil", []any{"a", nil}, Encode("a")},
+		{"several trailing absent", []any{"a", nil, missing, sql.NullInt64{}}, Encode("a")},
+		{"present pointer", []any{"a", &present}, Encode("a", "b")},
+		{"nil before present field", []any{"a", nil, "c"}, Encode("a", nil, "c")},
+		{"all absent", []any{nil, missing}, LexKey{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			key, err := EncodeOptional(tt.parts...)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, key)
+		})
+	}
+}
+
+func TestShouldSortAbsentTrailingFieldBeforePresentOne(t *testing.T) {
+	// Arrange
+	absent, err := EncodeOptional("a", sql.NullString{})
+	require.NoError(t, err)
+	present, err := EncodeOptional("a", sql.NullString{String: "", Valid: true}, int64(0))
+	require.NoError(t, err)
+
+	// Act / Assert
+	assert.Negative(t, Compare(absent, present))
+}
@@ -0,0 +1,18 @@
+package lexkey
+
+import "fmt"
+
+// EncodeOrdinal encodes a string enum by its position in order rather than by its text, so
+// values sort in a declared order (e.g. LOW < MEDIUM < HIGH) instead of alphabetically. The
+// ordinal is encoded as an int64 part, so the result equals Encode(int64(order[value])) and
+// negative ordinals sort first. Returns an error if value is not in order.
+//
+// The key stores only the ordinal: renumbering the table changes how existing keys sort and
+// decode, so append new values at unused positions.
+func EncodeOrdinal(value string, order map[string]int) (LexKey, error) {
+	n, ok := order[value]
+	if !ok {
+		return nil, fmt.Errorf("cannot encode ordinal: unknown value %q", value)
+	}
+	return Encode(int64(n)), nil
+}
@@ -0,0 +1,47 @@
+package lexkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPriorities = map[string]int{"LOW": 0, "MEDIUM": 1, "HIGH": 2}
+
+func TestShouldSortByOrdinalRatherThanAlphabetically(t *testing.T) {
+	// Arrange
+	values := []string{"LOW", "MEDIUM", "HIGH"}
+
+	// Act
+	keys := make([]LexKey, len(values))
+	for i, v := range values {
+		key, err := EncodeOrdinal(v, testPriorities)
+		require.NoError(t, err)
+		keys[i] = key
+	}
+
+	// Assert
+	assert.Negative(t, Compare(keys[0], keys[1]), "LOW < MEDIUM")
+	assert.Negative(t, Compare(keys[1], keys[2]), "MEDIUM < HIGH")
+	assert.Negative(t, Compare(Encode("HIGH"), Encode("MEDIUM")), "alphabetically HIGH < MEDIUM")
+}
+
+func TestShouldEncodeOrdinalAsInt64(t *testing.T) {
+	// Act
+	key, err := EncodeOrdinal("MEDIUM", testPriorities)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Encode(int64(1)), key)
+}
+
+func TestShouldRejectUnknownOrdinalValue(t *testing.T) {
+	// Act
+	key, err := EncodeOrdinal("URGENT", testPriorities)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, key)
+	assert.Contains(t, err.Error(), `"URGENT"`)
+}
@@ -0,0 +1,27 @@
+package lexkey
+
+import "fmt"
+
+// EncodePadded encodes s right-padded with pad to exactly width bytes, e.g. for string enums,
+// so a field has a fixed offset in multi-field keys and needs no separator or escaping to
+// find its end. Returns an error if s is longer than width bytes or width is negative.
+//
+// The pad byte affects ordering between a string and its extensions: with pad 0x00, "ab"
+// sorts before "abc" exactly as unpadded strings do; with a higher pad such as ' ' (0x20),
+// "ab" sorts after "ab\x01"; with 0xFF, shorter strings sort after all their extensions.
+// Strings ending in the pad byte cannot be told apart from shorter ones, so pick a pad that
+// never ends a value.
+func EncodePadded(s string, width int, pad byte) (LexKey, error) {
+	if width < 0 {
+		return nil, fmt.Errorf("EncodePadded: invalid width %d", width)
+	}
+	if len(s) > width {
+		return nil, fmt.Errorf("EncodePadded: %q is %d bytes, longer than width %d", s, len(s), width)
+	}
+	out := make(LexKey, width)
+	n := copy(out, s)
+	for i := n; i < width; i++ {
+		out[i] = pad
+	}
+	return out, nil
+}
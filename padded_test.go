@@ -0,0 +1,73 @@
+package lexkey
+
+import (
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldPadStringToWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		width    int
+		pad      byte
+		expected string
+	}{
+		{"zero pad", "ok", 5, 0x00, "6f6b000000"},
+		{"space pad", "ok", 4, ' ', "6f6b2020"},
+		{"exact width", "fail", 4, 0x00, "6661696c"},
+		{"empty", "", 2, 0xff, "ffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			key, err := EncodePadded(tt.s, tt.width, tt.pad)
+
+			// Assert
+			require.NoError(t, err)
+			test.AssertHexEqual(t, tt.expected, key)
+		})
+	}
+}
+
+func TestShouldRejectStringLongerThanWidth(t *testing.T) {
+	// Act
+	_, err := EncodePadded("pending", 4, 0x00)
+	_, errNegative := EncodePadded("", -1, 0x00)
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "longer than width 4")
+	require.Error(t, errNegative)
+}
+
+func TestShouldOrderPaddedStrings(t *testing.T) {
+	// Arrange
+	active, _ := EncodePadded("active", 8, 0x00)
+	activeX, _ := EncodePadded("activeX", 8, 0x00)
+	closed, _ := EncodePadded("closed", 8, 0x00)
+	highActive, _ := EncodePadded("active", 8, 0xff)
+	highActiveX, _ := EncodePadded("activeX", 8, 0xff)
+
+	// Assert
+	assert.Negative(t, Compare(active, closed))
+	assert.Negative(t, Compare(active, activeX), "zero padding keeps prefixes first")
+	assert.Positive(t, Compare(highActive, highActiveX), "0xff padding sorts prefixes last")
+}
+
+func TestShouldKeepFixedOffsetsInMultiFieldKeys(t *testing.T) {
+	// Arrange
+	short, _ := EncodePadded("a", 4, 0x00)
+	long, _ := EncodePadded("abcd", 4, 0x00)
+
+	// Act
+	k1 := Concat(short, Encode(int64(1)))
+	k2 := Concat(long, Encode(int64(1)))
+
+	// Assert: the second field starts at the same offset in both keys
+	assert.Equal(t, k1[5:], k2[5:])
+}
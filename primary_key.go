@@ -2,7 +2,9 @@ package lexkey
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
+	"fmt"
 )
 
 // NewPrimaryKey creates a new PrimaryKey from partition and row keys.
@@ -32,15 +34,90 @@ func (pk PrimaryKey) Encode() LexKey {
 	return result
 }
 
+// PartitionPrefix returns the PartitionKey followed by a Separator: the prefix shared by every
+// encoded PrimaryKey in the same partition.
+func (pk PrimaryKey) PartitionPrefix() LexKey {
+	return pk.PartitionKey.WithSeparator()
+}
+
+// InPartition reports whether key, an encoded PrimaryKey, belongs to pk's partition, without
+// decoding it. Because LexKey does not escape data, a partition key that is itself a
+// Separator-delimited prefix of another (e.g. Encode("a") and Encode("a", "b")) also
+// matches the longer partition's keys; this is only exact when partition keys contain no
+// 0x00 bytes or all have the same number of parts and widths.
+func (pk PrimaryKey) InPartition(key LexKey) bool {
+	n := len(pk.PartitionKey)
+	return len(key) > n && key[n] == Separator && bytes.Equal(key[:n], pk.PartitionKey)
+}
+
+// EncodeLengthPrefixed encodes the PrimaryKey as a 4-byte big-endian partition length,
+// the partition bytes, then the row bytes. Unlike Encode, the result can always be split
+// back unambiguously, even when the partition key contains 0x00 bytes.
+// Partitions sort by length first, so these keys only compare meaningfully with each other.
+// Returns an error if the partition key is longer than a uint32 length prefix can hold.
+func (pk PrimaryKey) EncodeLengthPrefixed() (LexKey, error) {
+	if err := checkLengthPrefix(len(pk.PartitionKey)); err != nil {
+		return nil, fmt.Errorf("cannot encode partition key: %w", err)
+	}
+	result := make(LexKey, lengthPrefixSize+len(pk.PartitionKey)+len(pk.RowKey))
+	binary.BigEndian.PutUint32(result, uint32(len(pk.PartitionKey)))
+	n := lengthPrefixSize + copy(result[lengthPrefixSize:], pk.PartitionKey)
+	copy(result[n:], pk.RowKey)
+	return result, nil
+}
+
 // DecodePrimaryKey decodes a PrimaryKey from its byte encoding.
 // Returns an error if the separator is missing or input is invalid.
 func DecodePrimaryKey(raw []byte) (PrimaryKey, error) {
-	sep := bytes.IndexByte(raw, Separator)
-	if sep < 0 {
+	partition, row, err := SplitPrimaryKey(raw)
+	if err != nil {
 		return PrimaryKey{}, errors.New("DecodePrimaryKey: missing separator")
 	}
 	return NewPrimaryKey(
-		append([]byte(nil), raw[:sep]...),
-		append([]byte(nil), raw[sep+1:]...),
+		append([]byte(nil), partition...),
+		append([]byte(nil), row...),
 	), nil
 }
+
+// SplitPrimaryKey splits an encoded PrimaryKey at the first Separator into its partition
+// and row portions. The returned keys share the backing array of encoded.
+//
+// LexKey does not escape data, so this is only reliable when the partition key contains
+// no 0x00 bytes (note that most numeric encodings do). Use EncodeLengthPrefixed and
+// SplitPrimaryKeyLengthPrefixed when partitions may contain 0x00.
+func SplitPrimaryKey(encoded LexKey) (partition, row LexKey, err error) {
+	sep := bytes.IndexByte(encoded, Separator)
+	if sep < 0 {
+		return nil, nil, errors.New("SplitPrimaryKey: missing separator")
+	}
+	return encoded[:sep], encoded[sep+1:], nil
+}
+
+// SplitAt splits an encoded PrimaryKey whose partition key is exactly partitionLen bytes
+// (e.g. a 16-byte UUID), skipping the Separator that follows it. Unlike SplitPrimaryKey this
+// never scans for a Separator, so it is exact even when the partition contains 0x00 bytes.
+// Returns an error if the key is shorter than partitionLen+1 or the byte at partitionLen is
+// not a Separator. The returned keys share the backing array of key.
+func SplitAt(key LexKey, partitionLen int) (partition, row LexKey, err error) {
+	if partitionLen < 0 || len(key) <= partitionLen {
+		return nil, nil, fmt.Errorf("SplitAt: key length %d too short for partition length %d", len(key), partitionLen)
+	}
+	if key[partitionLen] != Separator {
+		return nil, nil, fmt.Errorf("SplitAt: expected separator at offset %d, found 0x%02x", partitionLen, key[partitionLen])
+	}
+	return key[:partitionLen], key[partitionLen+1:], nil
+}
+
+// SplitPrimaryKeyLengthPrefixed splits a key produced by PrimaryKey.EncodeLengthPrefixed
+// into its partition and row portions. The returned keys share the backing array of encoded.
+func SplitPrimaryKeyLengthPrefixed(encoded LexKey) (partition, row LexKey, err error) {
+	if len(encoded) < lengthPrefixSize {
+		return nil, nil, errors.New("SplitPrimaryKeyLengthPrefixed: missing length prefix")
+	}
+	n := binary.BigEndian.Uint32(encoded)
+	if uint64(n) > uint64(len(encoded)-lengthPrefixSize) {
+		return nil, nil, fmt.Errorf("SplitPrimaryKeyLengthPrefixed: partition length %d exceeds key length", n)
+	}
+	end := lengthPrefixSize + int(n)
+	return encoded[lengthPrefixSize:end], encoded[end:], nil
+}
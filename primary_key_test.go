@@ -1,9 +1,11 @@
 package lexkey
 
 import (
+	"math"
 	"testing"
 
 	"github.com/fgrzl/lexkey/test"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -98,3 +100,192 @@ func TestShouldPanicWithExpectedMessageForNewPrimaryKey(t *testing.T) {
 		})
 	}
 }
+
+func TestShouldSplitPrimaryKeyAtFirstSeparator(t *testing.T) {
+	// Arrange
+	enc := NewPrimaryKey(Encode("partition"), Encode("row", "sub")).Encode()
+
+	// Act
+	partition, row, err := SplitPrimaryKey(enc)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Encode("partition"), partition)
+	assert.Equal(t, Encode("row", "sub"), row)
+}
+
+func TestShouldErrorWhenSplittingPrimaryKeyWithoutSeparator(t *testing.T) {
+	// Act
+	_, _, err := SplitPrimaryKey(LexKey("no_separator"))
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestShouldMisSplitSeparatorEncodedPartitionContainingZeroByte(t *testing.T) {
+	// Arrange: int64 partitions contain 0x00 bytes, so the first separator is inside the partition
+	pk := NewPrimaryKey(Encode(int64(7)), Encode("row"))
+
+	// Act
+	partition, _, err := SplitPrimaryKey(pk.Encode())
+
+	// Assert: documented limitation of separator-based splitting
+	require.NoError(t, err)
+	assert.NotEqual(t, pk.PartitionKey, partition)
+}
+
+func TestShouldSplitLengthPrefixedPrimaryKeyWithZeroBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		pk   PrimaryKey
+	}{
+		{"int partition", NewPrimaryKey(Encode(int64(7)), Encode("row"))},
+		{"embedded zero", NewPrimaryKey(LexKey{'a', 0x00, 'b'}, LexKey{0x00, 'r'})},
+		{"empty row", NewPrimaryKey(Encode("p"), LexKey{})},
+		{"empty partition", NewPrimaryKey(LexKey{}, Encode("r"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			encoded, encErr := tt.pk.EncodeLengthPrefixed()
+			partition, row, err := SplitPrimaryKeyLengthPrefixed(encoded)
+
+			// Assert
+			require.NoError(t, encErr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.pk.PartitionKey, partition)
+			assert.Equal(t, tt.pk.RowKey, row)
+		})
+	}
+}
+
+func TestShouldEncodeLengthPrefixedPrimaryKey(t *testing.T) {
+	// Arrange
+	pk := NewPrimaryKey(LexKey{'p', 0x00}, Encode("r"))
+
+	// Act
+	encoded, err := pk.EncodeLengthPrefixed()
+
+	// Assert
+	require.NoError(t, err)
+	test.AssertHexEqual(t, "00000002700072", encoded)
+}
+
+func TestShouldRejectLengthsBeyondUint32Prefix(t *testing.T) {
+	// Act / Assert
+	require.NoError(t, checkLengthPrefix(0))
+	require.NoError(t, checkLengthPrefix(math.MaxInt32))
+	if math.MaxInt == math.MaxInt32 {
+		t.Skip("int cannot hold a length above math.MaxUint32")
+	}
+	tooLong := int64(math.MaxUint32) + 1
+	err := checkLengthPrefix(int(tooLong))
+	assert.ErrorIs(t, err, ErrLengthOverflow)
+}
+
+func TestShouldErrorWhenLengthPrefixedPrimaryKeyIsMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  LexKey
+	}{
+		{"too short", LexKey{0x00, 0x00}},
+		{"length exceeds key", LexKey{0x00, 0x00, 0x00, 0x05, 'a'}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, _, err := SplitPrimaryKeyLengthPrefixed(tt.raw)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestShouldReturnPartitionPrefix(t *testing.T) {
+	// Arrange
+	pk := NewPrimaryKey(Encode("tenant"), Encode("row"))
+
+	// Act
+	prefix := pk.PartitionPrefix()
+
+	// Assert
+	test.AssertHexEqual(t, "74656e616e7400", prefix)
+	assert.Equal(t, pk.Encode()[:len(prefix)], prefix)
+}
+
+func TestShouldReportWhetherKeyIsInPartition(t *testing.T) {
+	pk := NewPrimaryKey(Encode("tenant"), Encode("row"))
+	tests := []struct {
+		name     string
+		key      LexKey
+		expected bool
+	}{
+		{"same partition", NewPrimaryKey(Encode("tenant"), Encode("other")).Encode(), true},
+		{"same partition, empty row", NewPrimaryKey(Encode("tenant"), Empty).Encode(), true},
+		{"different partition", NewPrimaryKey(Encode("other"), Encode("row")).Encode(), false},
+		{"partition with shared byte prefix", NewPrimaryKey(Encode("tenants"), Encode("row")).Encode(), false},
+		{"partition key alone", Encode("tenant"), false},
+		{"shorter key", Encode("ten"), false},
+		{"empty key", Empty, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act / Assert
+			assert.Equal(t, tt.expected, pk.InPartition(tt.key))
+		})
+	}
+}
+
+func TestShouldSplitAtFixedPartitionLength(t *testing.T) {
+	// Arrange
+	id := uuid.MustParse("00000000-0000-0000-0000-000000000001") // contains 0x00 bytes
+	key := NewPrimaryKey(Encode(id), Encode("row")).Encode()
+
+	// Act
+	partition, row, err := SplitAt(key, 16)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Encode(id), partition)
+	assert.Equal(t, Encode("row"), row)
+}
+
+func TestShouldSplitAtWithEmptyRow(t *testing.T) {
+	// Arrange
+	key := NewPrimaryKey(Encode("ab"), Empty).Encode()
+
+	// Act
+	partition, row, err := SplitAt(key, 2)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Encode("ab"), partition)
+	assert.Empty(t, row)
+}
+
+func TestShouldErrorWhenSplitAtLengthIsWrong(t *testing.T) {
+	key := NewPrimaryKey(Encode("abc"), Encode("row")).Encode()
+	tests := []struct {
+		name         string
+		partitionLen int
+	}{
+		{"too short partition length", 2},
+		{"too long partition length", 4},
+		{"beyond key length", len(key)},
+		{"negative", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, _, err := SplitAt(key, tt.partitionLen)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
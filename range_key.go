@@ -1,5 +1,10 @@
 package lexkey
 
+import (
+	"bytes"
+	"fmt"
+)
+
 // NewRangeKey creates a RangeKey for a given partition and row key range.
 // Panics if the partition key, lower, or upper key is nil.
 func NewRangeKey(partition, lower, upper LexKey) RangeKey {
@@ -37,17 +42,185 @@ type RangeKey struct {
 	PartitionKey LexKey
 	StartRowKey  LexKey
 	EndRowKey    LexKey
+
+	// StartAtPartitionBegin makes the lower bound the very beginning of the partition,
+	// before every possible row key, ignoring StartRowKey and the start inclusivity mode.
+	// An empty StartRowKey currently encodes to the same bound, but it means "start at
+	// the empty row key": it is a row key value, so a future row key encoding could place
+	// other rows before it. Set this flag when the intent is "from the first row".
+	StartAtPartitionBegin bool
 }
 
+// BoundsMode controls whether the start and end row keys are included in an encoded range.
+// Inclusion is prefix-based: including a row key also includes every key that extends it,
+// and excluding a row key also excludes its extensions. Empty row keys always leave that
+// side open (partition begin or end) regardless of mode.
+type BoundsMode uint8
+
+const (
+	// BoundsInclusiveInclusive includes both the start and end row keys (the Encode default).
+	BoundsInclusiveInclusive BoundsMode = iota
+	// BoundsInclusiveExclusive includes the start row key and excludes the end row key.
+	BoundsInclusiveExclusive
+	// BoundsExclusiveInclusive excludes the start row key and includes the end row key.
+	BoundsExclusiveInclusive
+	// BoundsExclusiveExclusive excludes both the start and end row keys.
+	BoundsExclusiveExclusive
+)
+
 // Encode encodes the range boundaries for range queries.
 func (rk RangeKey) Encode(withPartitionKey bool) (lower, upper LexKey) {
-	lower = encodeBoundary(rk.PartitionKey, rk.StartRowKey, false, withPartitionKey)
-	upper = encodeBoundary(rk.PartitionKey, rk.EndRowKey, true, withPartitionKey)
+	return rk.EncodeBounds(withPartitionKey, BoundsInclusiveInclusive)
+}
+
+// EncodeBounds encodes the range boundaries using the given inclusivity mode.
+// The result is always scanned as the half-open interval [lower, upper):
+// an included start is the row key itself, an excluded start is the row key followed by EndMarker;
+// an included end is the row key followed by EndMarker, an excluded end is the row key itself.
+func (rk RangeKey) EncodeBounds(withPartitionKey bool, mode BoundsMode) (lower, upper LexKey) {
+	excludeStart := mode == BoundsExclusiveInclusive || mode == BoundsExclusiveExclusive
+	includeEnd := mode == BoundsInclusiveInclusive || mode == BoundsExclusiveInclusive
+	startRowKey := rk.StartRowKey
+	if rk.StartAtPartitionBegin {
+		startRowKey, excludeStart = nil, false
+	}
+	lower = encodeBound(rk.PartitionKey, startRowKey, false, excludeStart, withPartitionKey)
+	upper = encodeBound(rk.PartitionKey, rk.EndRowKey, true, includeEnd, withPartitionKey)
 	return lower, upper
 }
 
+// Bounds holds the encoded bounds of a range, scanned as the half-open interval [Lower, Upper).
+type Bounds struct {
+	Lower LexKey
+	Upper LexKey
+}
+
+// Bounds returns the range boundaries as a single value; see Encode.
+func (rk RangeKey) Bounds(withPartitionKey bool) Bounds {
+	lower, upper := rk.Encode(withPartitionKey)
+	return Bounds{Lower: lower, Upper: upper}
+}
+
+// Contains reports whether Lower <= key < Upper.
+func (b Bounds) Contains(key LexKey) bool {
+	return Compare(b.Lower, key) <= 0 && Compare(key, b.Upper) < 0
+}
+
+// Width returns the byte length of the span once the prefix shared by Lower and Upper is
+// stripped: the longer bound's length minus the shared prefix's. Every key in the range starts
+// with that prefix, so Width measures how far below it the bounds reach, e.g. "ab" to "ac" has
+// Width 1. Equal bounds have Width 0 and a nil Upper shares no prefix. It is not a key count.
+func (b Bounds) Width() int {
+	n := min(len(b.Lower), len(b.Upper))
+	i := 0
+	for i < n && b.Lower[i] == b.Upper[i] {
+		i++
+	}
+	return max(len(b.Lower), len(b.Upper)) - i
+}
+
+// Contains reports whether key falls within the encoded range.
+// Bounds are half-open: lower <= key < upper. The lower bound is the start row key itself,
+// so a key equal to it is included. The upper bound is the end row key followed by EndMarker,
+// so the end row key and any extension of it are included, while the bound itself
+// (end row key + 0xFF) is excluded.
+func (rk RangeKey) Contains(key LexKey, withPartitionKey bool) bool {
+	return rk.Bounds(withPartitionKey).Contains(key)
+}
+
+// ContainsFast reports the same result as Contains without allocating: it compares key
+// against the partition, start and end row keys in place instead of materializing the
+// encoded bounds. Use it to filter many keys against one range on a hot path.
+func (rk RangeKey) ContainsFast(key LexKey, withPartitionKey bool) bool {
+	startRowKey := rk.StartRowKey
+	if rk.StartAtPartitionBegin {
+		startRowKey = nil
+	}
+	return compareBound(key, rk.PartitionKey, startRowKey, false, false, withPartitionKey) >= 0 &&
+		compareBound(key, rk.PartitionKey, rk.EndRowKey, true, true, withPartitionKey) < 0
+}
+
+// compareBound compares key with the bound encodeBound would build from the same arguments,
+// walking the bound's pieces (partition key, marker or Separator, row key, EndMarker) in turn.
+func compareBound(key, partitionKey, rowKey LexKey, isUpper, afterRow, withPartitionKey bool) int {
+	var c int
+	var done bool
+	if withPartitionKey {
+		if key, c, done = comparePiece(key, partitionKey); done {
+			return c
+		}
+	}
+	if len(rowKey) == 0 {
+		return finishCompare(compareByte(key, ternary(isUpper, EndMarker, Separator)))
+	}
+	if key, c, done = compareByte(key, Separator); done {
+		return c
+	}
+	if !afterRow {
+		return finishCompare(comparePiece(key, rowKey))
+	}
+	if key, c, done = comparePiece(key, rowKey); done {
+		return c
+	}
+	return finishCompare(compareByte(key, EndMarker))
+}
+
+// comparePiece compares the front of key with piece, the next part of a bound. done is false
+// when key starts with piece and rest is key after it; otherwise c orders key against the bound.
+func comparePiece(key, piece []byte) (rest []byte, c int, done bool) {
+	n := min(len(key), len(piece))
+	if c = bytes.Compare(key[:n], piece[:n]); c != 0 {
+		return nil, c, true
+	}
+	if len(key) < len(piece) {
+		return nil, -1, true
+	}
+	return key[n:], 0, false
+}
+
+// compareByte is comparePiece for a single-byte piece.
+func compareByte(key []byte, b byte) (rest []byte, c int, done bool) {
+	switch {
+	case len(key) == 0 || key[0] < b:
+		return nil, -1, true
+	case key[0] > b:
+		return nil, 1, true
+	}
+	return key[1:], 0, false
+}
+
+// finishCompare turns the comparison of the bound's last piece into the final result: a key
+// that matched the whole bound sorts after it when bytes remain.
+func finishCompare(rest []byte, c int, done bool) int {
+	if done || len(rest) == 0 {
+		return c
+	}
+	return 1
+}
+
+// Validate returns an error if the range is inverted: its encoded lower bound does not sort
+// before its upper bound, so a scan would silently return nothing. A range whose start and end
+// row keys are equal is valid and matches that row key. Validation uses the inclusive Encode
+// bounds; an exclusive mode can still make a valid range empty, e.g. excluding both ends of a
+// single-row range.
+func (rk RangeKey) Validate() error {
+	lower, upper := rk.Encode(true)
+	if Compare(lower, upper) >= 0 {
+		return fmt.Errorf("invalid range: start row key %s sorts after end row key %s",
+			rk.StartRowKey.ToHexString(), rk.EndRowKey.ToHexString())
+	}
+	return nil
+}
+
 // encodeBoundary encodes range boundaries for lexicographic ordering.
 func encodeBoundary(partitionKey, rowKey LexKey, isUpper, withPartitionKey bool) LexKey {
+	return encodeBound(partitionKey, rowKey, isUpper, isUpper, withPartitionKey)
+}
+
+// encodeBound encodes a single range boundary. An empty rowKey yields the open partition
+// boundary (Separator for lower, EndMarker for upper); otherwise afterRow appends EndMarker
+// so the bound sorts after the row key and all of its extensions.
+func encodeBound(partitionKey, rowKey LexKey, isUpper, afterRow, withPartitionKey bool) LexKey {
 	var size int
 	if withPartitionKey {
 		size = len(partitionKey)
@@ -55,7 +228,7 @@ func encodeBoundary(partitionKey, rowKey LexKey, isUpper, withPartitionKey bool)
 	if len(rowKey) > 0 {
 		size += len(rowKey)
 		size++ // Separator + rowKey
-		if isUpper {
+		if afterRow {
 			size++ // extra byte for end marker
 		}
 	} else {
@@ -73,7 +246,7 @@ func encodeBoundary(partitionKey, rowKey LexKey, isUpper, withPartitionKey bool)
 		result[n] = Separator
 		n++
 		copy(result[n:], rowKey)
-		if isUpper {
+		if afterRow {
 			result[len(result)-1] = EndMarker
 		}
 	}
@@ -86,3 +259,39 @@ func ternary(cond bool, a, b byte) byte {
 	}
 	return b
 }
+
+// BoundingRange returns the tightest half-open range [lower, upper) containing every key:
+// lower is a copy of the smallest key and upper is the Next of the largest. Both are nil
+// when keys is empty. The keys need not be sorted.
+func BoundingRange(keys []LexKey) (lower, upper LexKey) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	lo, hi := keys[0], keys[0]
+	for _, k := range keys[1:] {
+		if Compare(k, lo) < 0 {
+			lo = k
+		}
+		if Compare(k, hi) > 0 {
+			hi = k
+		}
+	}
+	return append(LexKey{}, lo...), hi.Next()
+}
+
+// SplitRangeAround returns the whole partition minus one row: before covers the rows
+// sorting before exclude and after covers the rows sorting after it, up to the partition key
+// followed by EndMarker (the open upper bound of an empty EndRowKey), so every row of the
+// partition lands in one of them. Both are encoded with the partition key, like
+// PrimaryKey.Encode. Because Bounds are half-open, before ends at
+// the excluded key itself and after starts at its Next; no Prev is needed. Extensions of
+// exclude (e.g. row Encode("a", 1) when excluding Encode("a")) sort after it and fall in after.
+// As with any EndMarker bound on unescaped keys, a partition key extending this one by raw
+// bytes (Encode("parts") for Encode("part")) also sorts inside after.
+// Panics if either key is nil.
+func SplitRangeAround(partition, exclude LexKey) (before, after Bounds) {
+	key := NewPrimaryKey(partition, exclude).Encode()
+	before = Bounds{Lower: encodeBound(partition, nil, false, false, true), Upper: key}
+	after = Bounds{Lower: key.Next(), Upper: encodeBound(partition, nil, true, false, true)}
+	return before, after
+}
@@ -1,6 +1,8 @@
 package lexkey
 
 import (
+	"math"
+
 	"testing"
 
 	"github.com/fgrzl/lexkey/test"
@@ -70,3 +72,411 @@ func TestShouldEncodeEmptyRowKeyBoundariesCorrectly(t *testing.T) {
 	test.AssertHexEqual(t, "706172746974696f6e00", lower)
 	test.AssertHexEqual(t, "706172746974696f6eff", upper)
 }
+
+func TestShouldContainKeysWithinRangeBoundaries(t *testing.T) {
+	// Arrange
+	rk := NewRangeKey(Encode("part"), Encode("b"), Encode("d"))
+	lower, upper := rk.Encode(true)
+
+	tests := []struct {
+		name     string
+		key      LexKey
+		expected bool
+	}{
+		{"exactly lower", lower, true},
+		{"before lower", Encode("part", "a"), false},
+		{"inside", Encode("part", "c"), true},
+		{"exactly end row", Encode("part", "d"), true},
+		{"extension of end row", Encode("part", "d", 42), true},
+		{"just below end marker", append(Encode("part", "d"), 0xFE), true},
+		{"exactly upper", upper, false},
+		{"after upper", Encode("part", "e"), false},
+		{"other partition", Encode("other", "c"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act / Assert
+			assert.Equal(t, tt.expected, rk.Contains(tt.key, true))
+		})
+	}
+}
+
+func TestShouldContainKeysWithoutPartitionKey(t *testing.T) {
+	// Arrange
+	rk := NewRangeKey(Encode("part"), Encode("b"), Encode("d"))
+
+	// Act / Assert
+	assert.True(t, rk.Contains(LexKey{Separator, 'c'}, false))
+	assert.False(t, rk.Contains(LexKey{Separator, 'e'}, false))
+}
+
+func TestShouldContainWholePartitionForFullRange(t *testing.T) {
+	// Arrange
+	rk := NewRangeKeyFull(Encode("part"))
+
+	// Act / Assert
+	assert.True(t, rk.Contains(Encode("part", ""), true))
+	assert.True(t, rk.Contains(Encode("part", "zzz"), true))
+	assert.True(t, rk.Contains(Encode("part", Last), true))
+	assert.False(t, rk.Contains(append(Encode("part"), EndMarker), true))
+	assert.False(t, rk.Contains(Encode("parts"), true))
+}
+
+func TestShouldEncodeBoundsForEachInclusivityMode(t *testing.T) {
+	// Arrange
+	rk := NewRangeKey(Encode("p"), Encode("b"), Encode("d"))
+	keys := map[string]LexKey{
+		"a":  Encode("p", "a"),
+		"b":  Encode("p", "b"),
+		"b1": Encode("p", "b", 1),
+		"c":  Encode("p", "c"),
+		"d":  Encode("p", "d"),
+		"d1": Encode("p", "d", 1),
+		"e":  Encode("p", "e"),
+	}
+
+	tests := []struct {
+		name     string
+		mode     BoundsMode
+		expected []string
+	}{
+		{"inclusive/inclusive", BoundsInclusiveInclusive, []string{"b", "b1", "c", "d", "d1"}},
+		{"inclusive/exclusive", BoundsInclusiveExclusive, []string{"b", "b1", "c"}},
+		{"exclusive/inclusive", BoundsExclusiveInclusive, []string{"c", "d", "d1"}},
+		{"exclusive/exclusive", BoundsExclusiveExclusive, []string{"c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			lower, upper := rk.EncodeBounds(true, tt.mode)
+
+			// Assert
+			var got []string
+			for _, name := range []string{"a", "b", "b1", "c", "d", "d1", "e"} {
+				k := keys[name]
+				if Compare(lower, k) <= 0 && Compare(k, upper) < 0 {
+					got = append(got, name)
+				}
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestShouldMatchEncodeForDefaultBoundsMode(t *testing.T) {
+	// Arrange
+	rk := NewRangeKey(Encode("part"), Encode("start"), Encode("end"))
+
+	// Act
+	lower, upper := rk.EncodeBounds(true, BoundsInclusiveInclusive)
+	wantLower, wantUpper := rk.Encode(true)
+
+	// Assert
+	assert.Equal(t, wantLower, lower)
+	assert.Equal(t, wantUpper, upper)
+}
+
+func TestShouldKeepEmptyRowKeysOpenForAllBoundsModes(t *testing.T) {
+	// Arrange
+	rk := NewRangeKey(Encode("part"), Empty, Empty)
+
+	for _, mode := range []BoundsMode{BoundsInclusiveInclusive, BoundsInclusiveExclusive, BoundsExclusiveInclusive, BoundsExclusiveExclusive} {
+		// Act
+		lower, upper := rk.EncodeBounds(true, mode)
+
+		// Assert
+		test.AssertHexEqual(t, "7061727400", lower)
+		test.AssertHexEqual(t, "70617274ff", upper)
+	}
+}
+
+func TestShouldStartAtPartitionBeginIgnoringStartRowKey(t *testing.T) {
+	// Arrange
+	rk := NewRangeKey(Encode("part"), Encode("m"), Encode("z"))
+	rk.StartAtPartitionBegin = true
+
+	// Act
+	lower, upper := rk.EncodeBounds(true, BoundsExclusiveInclusive)
+
+	// Assert
+	test.AssertHexEqual(t, "7061727400", lower)
+	test.AssertHexEqual(t, "70617274007aff", upper)
+	assert.True(t, rk.Contains(NewPrimaryKey(Encode("part"), Encode("a")).Encode(), true))
+}
+
+func TestShouldPlacePartitionBeginBeforeAnyRowKey(t *testing.T) {
+	// Arrange
+	partition := Encode("part")
+	rk := RangeKey{PartitionKey: partition, EndRowKey: Last, StartAtPartitionBegin: true}
+	rows := []LexKey{
+		Encode(nil),
+		Encode(""),
+		Encode(int64(math.MinInt64)),
+		Encode(math.Inf(-1)),
+		Encode(false),
+		Encode(uint64(0)),
+		Encode("a"),
+	}
+
+	for _, withPartitionKey := range []bool{true, false} {
+		lower, _ := rk.Encode(withPartitionKey)
+		for _, row := range rows {
+			key := NewPrimaryKey(partition, row).Encode()
+			if !withPartitionKey {
+				key = append(LexKey{Separator}, row...)
+			}
+
+			// Assert
+			assert.LessOrEqual(t, Compare(lower, key), 0, "row %x, withPartitionKey=%v", row, withPartitionKey)
+		}
+	}
+}
+
+func TestShouldMatchEncodeWithBounds(t *testing.T) {
+	// Arrange
+	rk := NewRangeKey(Encode("part"), Encode("start"), Encode("end"))
+
+	for _, withPartitionKey := range []bool{true, false} {
+		// Act
+		lower, upper := rk.Encode(withPartitionKey)
+		bounds := rk.Bounds(withPartitionKey)
+
+		// Assert
+		assert.Equal(t, lower, bounds.Lower)
+		assert.Equal(t, upper, bounds.Upper)
+		assert.Equal(t, max(len(lower), len(upper))-len(CommonPrefix(lower, upper)), bounds.Width())
+	}
+}
+
+func TestShouldMeasureBoundsWidthBelowSharedPrefix(t *testing.T) {
+	tests := []struct {
+		name   string
+		bounds Bounds
+		want   int
+	}{
+		{"equal bounds", Bounds{Lower: LexKey("ab"), Upper: LexKey("ab")}, 0},
+		{"last byte differs", Bounds{Lower: LexKey("ab"), Upper: LexKey("ac")}, 1},
+		{"prefix range", Bounds{Lower: LexKey("ab"), Upper: LexKey("ab\xff")}, 1},
+		{"longer lower", Bounds{Lower: LexKey("abcd"), Upper: LexKey("ac")}, 3},
+		{"no shared prefix", Bounds{Lower: LexKey("a"), Upper: LexKey("b\x00")}, 2},
+		{"unbounded upper", Bounds{Lower: LexKey("abc")}, 3},
+		{"row range", NewRangeKey(Encode("part"), Encode("start"), Encode("end")).Bounds(true), 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			width := tt.bounds.Width()
+
+			// Assert
+			assert.Equal(t, tt.want, width)
+		})
+	}
+}
+
+func TestShouldContainKeysWithinBounds(t *testing.T) {
+	// Arrange
+	bounds := Bounds{Lower: Encode("b"), Upper: Encode("d")}
+
+	// Act / Assert
+	assert.False(t, bounds.Contains(Encode("a")))
+	assert.True(t, bounds.Contains(Encode("b")))
+	assert.True(t, bounds.Contains(Encode("c", 1)))
+	assert.False(t, bounds.Contains(Encode("d")))
+}
+
+func TestShouldComputeBoundingRange(t *testing.T) {
+	tests := []struct {
+		name         string
+		keys         []LexKey
+		lower, upper LexKey
+	}{
+		{"single key", []LexKey{Encode("m")}, Encode("m"), LexKey{'m', 0x00}},
+		{"unsorted keys", []LexKey{Encode("m"), Encode("a", 1), Encode("z"), Encode("b")}, Encode("a", 1), LexKey{'z', 0x00}},
+		{"empty input", nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			lower, upper := BoundingRange(tt.keys)
+
+			// Assert
+			assert.Equal(t, tt.lower, lower)
+			assert.Equal(t, tt.upper, upper)
+			bounds := Bounds{Lower: lower, Upper: upper}
+			for _, k := range tt.keys {
+				assert.True(t, bounds.Contains(k), "bounds should contain %x", k)
+			}
+		})
+	}
+}
+
+func TestShouldExcludeExtensionsOfLargestKeyFromBoundingRange(t *testing.T) {
+	// Arrange
+	keys := []LexKey{Encode("a"), Encode("b")}
+
+	// Act
+	lower, upper := BoundingRange(keys)
+
+	// Assert
+	bounds := Bounds{Lower: lower, Upper: upper}
+	assert.False(t, bounds.Contains(Encode("b", "c")))
+	assert.False(t, bounds.Contains(Encode("c")))
+	assert.True(t, bounds.Contains(Encode("a", "z")))
+}
+
+func TestShouldValidateRangeKeyOrder(t *testing.T) {
+	// Arrange
+	partition := Encode("p")
+	tests := []struct {
+		name    string
+		rk      RangeKey
+		wantErr bool
+	}{
+		{"ascending", NewRangeKey(partition, Encode("a"), Encode("b")), false},
+		{"equal start and end", NewRangeKey(partition, Encode("a"), Encode("a")), false},
+		{"start is prefix of end", NewRangeKey(partition, Encode("a"), Encode("a", 1)), false},
+		{"full partition", NewRangeKeyFull(partition), false},
+		{"open start", NewRangeKey(partition, Empty, Encode("a")), false},
+		{"inverted", NewRangeKey(partition, Encode("b"), Encode("a")), true},
+		{"inverted integers", NewRangeKey(partition, Encode(10), Encode(-10)), true},
+		{"extension of end", NewRangeKey(partition, Encode("a", 1), Encode("a")), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.rk.Validate()
+
+			// Assert
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "sorts after end row key")
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestShouldSplitRangeAroundExcludedKey(t *testing.T) {
+	// Arrange
+	partition := Encode("part")
+	before, after := SplitRangeAround(partition, Encode("m"))
+	tests := []struct {
+		name     string
+		key      LexKey
+		inBefore bool
+		inAfter  bool
+	}{
+		{"empty row", Encode("part", ""), true, false},
+		{"row before", Encode("part", "a"), true, false},
+		{"extension of row before", Encode("part", "l", Last), true, false},
+		{"large int row", Encode("part", int64(1000)), false, true},
+		{"negative int row", Encode("part", int64(-1)), false, true},
+		{"excluded row", Encode("part", "m"), false, false},
+		{"extension of excluded row", Encode("part", "m", 1), false, true},
+		{"row after", Encode("part", "n"), false, true},
+		{"high-byte string row", Encode("part", "é"), false, true},
+		{"0xFF string row", Encode("part", "\xff\xff"), false, true},
+		{"max uint64 row", Encode("part", uint64(math.MaxUint64)), false, true},
+		{"Last row", Encode("part", Last), false, true},
+		{"partition upper bound", append(Encode("part"), EndMarker), false, false},
+		{"other partition", Encode("other", "m"), false, false},
+		{"unescaped lookalike partition", Encode("parts", "m"), false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			inBefore, inAfter := before.Contains(tt.key), after.Contains(tt.key)
+
+			// Assert
+			assert.Equal(t, tt.inBefore, inBefore, "before")
+			assert.Equal(t, tt.inAfter, inAfter, "after")
+		})
+	}
+}
+
+func TestShouldEndSplitRangeAtPartitionEndMarker(t *testing.T) {
+	// Act
+	before, after := SplitRangeAround(Encode("part"), Encode("m"))
+
+	// Assert
+	test.AssertHexEqual(t, "7061727400", before.Lower)
+	test.AssertHexEqual(t, "70617274ff", after.Upper)
+}
+
+func TestShouldSplitRangeAroundEmptyRowKey(t *testing.T) {
+	// Arrange
+	partition := Encode("part")
+
+	// Act
+	before, after := SplitRangeAround(partition, Empty)
+
+	// Assert
+	assert.Equal(t, before.Lower, before.Upper, "nothing sorts before the empty row key")
+	assert.False(t, after.Contains(Encode("part", "")))
+	assert.True(t, after.Contains(Encode("part", "a")))
+}
+
+func TestShouldMatchContainsWithContainsFast(t *testing.T) {
+	// Arrange
+	partition := Encode("part")
+	ranges := []RangeKey{
+		NewRangeKey(partition, Encode("b"), Encode("d")),
+		NewRangeKey(partition, Encode("b"), Encode("b")),
+		NewRangeKey(partition, Empty, Encode("c")),
+		NewRangeKey(partition, Encode("c"), Empty),
+		NewRangeKeyFull(partition),
+		{PartitionKey: partition, StartRowKey: Encode("c"), EndRowKey: Last, StartAtPartitionBegin: true},
+	}
+	keys := []LexKey{
+		nil,
+		Encode("part"),
+		Encode("part", ""),
+		Encode("part", "a"),
+		Encode("part", "b"),
+		Encode("part", "b", 1),
+		Encode("part", "c"),
+		Encode("part", "d"),
+		append(Encode("part", "d"), EndMarker),
+		Encode("part", "d", Last),
+		Encode("part", "e"),
+		Encode("part", Last),
+		append(Encode("part"), EndMarker),
+		Encode("parts"),
+		Encode("other", "c"),
+		{Separator},
+		{Separator, 'c'},
+		Encode("c"),
+		{EndMarker},
+	}
+
+	for i, rk := range ranges {
+		for _, key := range keys {
+			for _, withPartitionKey := range []bool{true, false} {
+				// Act
+				got := rk.ContainsFast(key, withPartitionKey)
+
+				// Assert
+				assert.Equal(t, rk.Contains(key, withPartitionKey), got, "range %d, key %x, withPartitionKey %v", i, key, withPartitionKey)
+			}
+		}
+	}
+}
+
+func TestShouldNotAllocateInContainsFast(t *testing.T) {
+	// Arrange
+	rk := NewRangeKey(Encode("part"), Encode("b"), Encode("d"))
+	key := Encode("part", "c")
+
+	// Act
+	allocs := testing.AllocsPerRun(100, func() { _ = rk.ContainsFast(key, true) })
+
+	// Assert
+	assert.Zero(t, allocs)
+}
@@ -0,0 +1,122 @@
+package lexkey
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// RatDigits is the number of significant decimal digits kept when encoding a *big.Rat.
+// Rationals whose decimal expansion terminates within RatDigits digits (e.g. 3/8) encode
+// exactly and produce the same bytes as the equal Decimal; longer expansions (e.g. 1/3) are
+// truncated toward zero. Truncation preserves order but not distinctness: two rationals that
+// agree in their first RatDigits significant digits encode identically.
+const RatDigits = 40
+
+// ratMaxExponent bounds the decimal exponent of an encoded *big.Rat, so decoding a key never
+// scales by an arbitrarily large power of ten: a 6-byte key could otherwise demand 10^(2^31).
+// Rationals with magnitudes outside 10^±ratMaxExponent are rejected when encoding.
+const ratMaxExponent = 10000
+
+var bigTen = big.NewInt(10)
+
+// ratParts returns the sign, the significant digits (at most RatDigits, no trailing zeros)
+// and the adjusted exponent of the leading digit of a non-zero r, in the form used by
+// decimalParts.
+func ratParts(r *big.Rat) (negative bool, digits string, adjusted int32, err error) {
+	num := new(big.Int).Abs(r.Num())
+	den := r.Denom()
+
+	// num/den lies in (10^(ln-ld-1), 10^(ln-ld+1)), so the exponent is ln-ld or one less.
+	exp := int64(len(num.String()) - len(den.String()))
+	if ratCompareScaled(num, den, exp) < 0 {
+		exp--
+	}
+	if exp < -ratMaxExponent || exp > ratMaxExponent {
+		return false, "", 0, fmt.Errorf("rational exponent %d out of range ±%d", exp, ratMaxExponent)
+	}
+
+	// floor(num × 10^(RatDigits-1-exp) / den) has exactly RatDigits digits.
+	shift := int64(RatDigits-1) - exp
+	scaledNum, scaledDen := new(big.Int).Set(num), new(big.Int).Set(den)
+	if shift >= 0 {
+		scaledNum.Mul(scaledNum, new(big.Int).Exp(bigTen, big.NewInt(shift), nil))
+	} else {
+		scaledDen.Mul(scaledDen, new(big.Int).Exp(bigTen, big.NewInt(-shift), nil))
+	}
+	digits = strings.TrimRight(scaledNum.Quo(scaledNum, scaledDen).String(), "0")
+	return r.Sign() < 0, digits, int32(exp), nil
+}
+
+// ratCompareScaled compares num with den × 10^exp.
+func ratCompareScaled(num, den *big.Int, exp int64) int {
+	if exp >= 0 {
+		return num.Cmp(new(big.Int).Mul(den, new(big.Int).Exp(bigTen, big.NewInt(exp), nil)))
+	}
+	return new(big.Int).Mul(num, new(big.Int).Exp(bigTen, big.NewInt(-exp), nil)).Cmp(den)
+}
+
+// ratDecimal is a *big.Rat canonicalized to the parts of its Decimal encoding, so the
+// big.Int scaling in ratParts runs once per encode rather than once for sizing and again
+// for writing. Zero has no digits; err is returned when encoding.
+type ratDecimal struct {
+	negative bool
+	digits   string
+	adjusted int32
+	err      error
+}
+
+// newRatDecimal computes the encoding parts of a non-nil r.
+func newRatDecimal(r *big.Rat) ratDecimal {
+	if r.Sign() == 0 {
+		return ratDecimal{}
+	}
+	negative, digits, adjusted, err := ratParts(r)
+	return ratDecimal{negative: negative, digits: digits, adjusted: adjusted, err: err}
+}
+
+// ratSize returns the encoded size of r; see encodeRat.
+func ratSize(r ratDecimal) int {
+	if r.digits == "" {
+		return 1 // zero, or will error during encoding
+	}
+	return decimalDigitsSize(r.negative, r.digits)
+}
+
+// encodeRat writes r in the Decimal encoding, after rounding it to RatDigits significant
+// digits toward zero.
+func encodeRat(dst []byte, r ratDecimal) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if r.digits == "" {
+		dst[0] = decimalZero
+		return 1, nil
+	}
+	return encodeDecimalDigits(dst, r.negative, r.digits, r.adjusted)
+}
+
+// decodeRat decodes a *big.Rat segment. Values that were truncated to RatDigits decode as
+// the truncated decimal, e.g. 1/3 decodes as 0.333…3 with RatDigits threes.
+func decodeRat(seg []byte) (*big.Rat, error) {
+	if len(seg) == 1 && seg[0] == decimalZero {
+		return new(big.Rat), nil
+	}
+	negative, digits, adjusted, err := decodeDecimalDigits(seg)
+	if err != nil {
+		return nil, err
+	}
+	num, _ := new(big.Int).SetString(digits, 10) // digits were validated
+	if negative {
+		num.Neg(num)
+	}
+	if adjusted < -ratMaxExponent || adjusted > ratMaxExponent {
+		return nil, fmt.Errorf("rational exponent %d out of range ±%d", adjusted, ratMaxExponent)
+	}
+	exp := int64(adjusted) - int64(len(digits)) + 1
+	scale := new(big.Int).Exp(bigTen, big.NewInt(max(exp, -exp)), nil)
+	if exp >= 0 {
+		return new(big.Rat).SetInt(num.Mul(num, scale)), nil
+	}
+	return new(big.Rat).SetFrac(num, scale), nil
+}
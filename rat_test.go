@@ -0,0 +1,142 @@
+package lexkey
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldSortRationalsNumerically(t *testing.T) {
+	// Arrange: ascending numeric order, including negatives and non-terminating expansions
+	values := []*big.Rat{
+		big.NewRat(-1000, 3),
+		big.NewRat(-2, 3),
+		big.NewRat(-1, 2),
+		big.NewRat(-1, 3),
+		big.NewRat(-1, 1000000007),
+		big.NewRat(0, 1),
+		big.NewRat(1, 1000000007),
+		big.NewRat(1, 3),
+		big.NewRat(1, 2),
+		big.NewRat(2, 3),
+		big.NewRat(1, 1),
+		big.NewRat(22, 7),
+		big.NewRat(1000, 3),
+	}
+
+	// Act
+	keys := make([]LexKey, len(values))
+	for i, v := range values {
+		keys[i] = Encode(v)
+	}
+
+	// Assert
+	for i := 1; i < len(keys); i++ {
+		assert.Negative(t, Compare(keys[i-1], keys[i]), "%s should sort before %s", values[i-1], values[i])
+	}
+}
+
+func TestShouldEncodeTerminatingRationalsLikeDecimals(t *testing.T) {
+	tests := []struct {
+		name     string
+		rat      *big.Rat
+		expected Decimal
+	}{
+		{"three eighths", big.NewRat(3, 8), Decimal{Coefficient: 375, Exponent: -3}},
+		{"negative one and a half", big.NewRat(-3, 2), Decimal{Coefficient: -15, Exponent: -1}},
+		{"integer", big.NewRat(1200, 1), Decimal{Coefficient: 12, Exponent: 2}},
+		{"zero", new(big.Rat), Decimal{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			key, err := NewLexKey(tt.rat)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, Encode(tt.expected), key)
+			assert.Len(t, key, EncodeSize(tt.rat))
+		})
+	}
+}
+
+func TestShouldTruncateRationalsToRatDigits(t *testing.T) {
+	// Arrange: 1/3 and 0.333…3 with RatDigits threes agree in every kept digit
+	third := big.NewRat(1, 3)
+	truncated, ok := new(big.Rat).SetString("0." + strings.Repeat("3", RatDigits))
+	require.True(t, ok)
+
+	// Act / Assert
+	assert.Equal(t, Encode(truncated), Encode(third))
+	assert.Len(t, Encode(third), 1+4+RatDigits)
+}
+
+func TestShouldEncodeRationalValuesAndNilPointers(t *testing.T) {
+	// Arrange
+	var nilRat *big.Rat
+
+	// Act / Assert
+	assert.Equal(t, Encode(big.NewRat(1, 2)), Encode(*big.NewRat(1, 2)))
+	assert.Equal(t, Encode(nil), Encode(nilRat))
+}
+
+func TestShouldRejectRationalExponentOutOfRangeWhenDecoding(t *testing.T) {
+	// Arrange: a positive decimal with an adjusted exponent of 2^31 - 16
+	key := LexKey{0x03, 0xff, 0xff, 0xff, 0xf0, '1'}
+
+	// Act
+	_, err := Decode(key, reflect.TypeOf((*big.Rat)(nil)))
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "out of range")
+}
+
+func TestShouldRejectRationalExponentOutOfRangeWhenEncoding(t *testing.T) {
+	// Arrange
+	huge := new(big.Rat).SetInt(new(big.Int).Exp(bigTen, big.NewInt(ratMaxExponent+1), nil))
+	limit := new(big.Rat).SetInt(new(big.Int).Exp(bigTen, big.NewInt(ratMaxExponent), nil))
+
+	// Act
+	_, errHuge := NewLexKey(huge)
+	_, errTiny := NewLexKey(new(big.Rat).Inv(huge))
+	key, errLimit := NewLexKey(limit)
+
+	// Assert
+	require.Error(t, errHuge)
+	require.Error(t, errTiny)
+	require.NoError(t, errLimit)
+	values, err := Decode(key, reflect.TypeOf((*big.Rat)(nil)))
+	require.NoError(t, err)
+	assert.Zero(t, limit.Cmp(values[0].(*big.Rat)))
+}
+
+func TestShouldSizeRationalsExactly(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{"zero", new(big.Rat)},
+		{"positive", big.NewRat(3, 8)},
+		{"negative", big.NewRat(-1, 3)},
+		{"value", *big.NewRat(22, 7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			key, err := NewLexKey(tt.value, "x")
+			size, sizeErr := EncodedSize(tt.value, "x")
+
+			// Assert
+			require.NoError(t, err)
+			require.NoError(t, sizeErr)
+			assert.Len(t, key, size)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package lexkey
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EncodeRecord encodes the fields of record named by fieldOrder as a composite key, in that
+// order, like NewLexKey(record[fieldOrder[0]], record[fieldOrder[1]], ...). It bridges
+// map-based data models and positional keys without declaring types; use a Schema when
+// the field types should be checked and the key decoded back.
+//
+// Returns an error if fieldOrder is empty or repeats a name, or a named field is missing from
+// record (a present nil value encodes as nil). Fields of record not named in fieldOrder are
+// ignored, so a full row can be passed to build a key from some of its columns.
+func EncodeRecord(fieldOrder []string, record map[string]any) (LexKey, error) {
+	if len(fieldOrder) == 0 {
+		return LexKey([]byte{}), errors.New("cannot encode record: no fields in order")
+	}
+	parts := make([]any, len(fieldOrder))
+	for i, name := range fieldOrder {
+		for _, prev := range fieldOrder[:i] {
+			if prev == name {
+				return LexKey([]byte{}), fmt.Errorf("cannot encode record: duplicate field %q", name)
+			}
+		}
+		v, ok := record[name]
+		if !ok {
+			return LexKey([]byte{}), fmt.Errorf("cannot encode record: missing field %q", name)
+		}
+		parts[i] = v
+	}
+	key, err := NewLexKey(parts...)
+	if err != nil {
+		return LexKey([]byte{}), fmt.Errorf("cannot encode record: %w", err)
+	}
+	return key, nil
+}
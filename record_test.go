@@ -0,0 +1,73 @@
+package lexkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldEncodeRecordFieldsInGivenOrder(t *testing.T) {
+	// Arrange
+	record := map[string]any{"tenant": "acme", "id": int64(42), "region": "eu", "note": "ignored"}
+
+	// Act
+	byTenant, errTenant := EncodeRecord([]string{"tenant", "region", "id"}, record)
+	byRegion, errRegion := EncodeRecord([]string{"region", "tenant", "id"}, record)
+
+	// Assert
+	require.NoError(t, errTenant)
+	require.NoError(t, errRegion)
+	assert.Equal(t, Encode("acme", "eu", int64(42)), byTenant)
+	assert.Equal(t, Encode("eu", "acme", int64(42)), byRegion)
+}
+
+func TestShouldSortRecordsByFieldOrder(t *testing.T) {
+	// Arrange
+	order := []string{"region", "tenant"}
+	a := map[string]any{"tenant": "zeta", "region": "eu"}
+	b := map[string]any{"tenant": "acme", "region": "us"}
+
+	// Act
+	keyA, errA := EncodeRecord(order, a)
+	keyB, errB := EncodeRecord(order, b)
+
+	// Assert: region decides before tenant
+	require.NoError(t, errA)
+	require.NoError(t, errB)
+	assert.Negative(t, Compare(keyA, keyB))
+}
+
+func TestShouldRejectInvalidRecordsInEncodeRecord(t *testing.T) {
+	tests := []struct {
+		name   string
+		order  []string
+		record map[string]any
+		errMsg string
+	}{
+		{"missing field", []string{"tenant", "id"}, map[string]any{"tenant": "acme"}, `missing field "id"`},
+		{"duplicate field", []string{"id", "id"}, map[string]any{"id": 1}, `duplicate field "id"`},
+		{"no fields", nil, map[string]any{"id": 1}, "no fields"},
+		{"unsupported value", []string{"id"}, map[string]any{"id": []int{1}}, "unsupported type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := EncodeRecord(tt.order, tt.record)
+
+			// Assert
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestShouldEncodePresentNilRecordField(t *testing.T) {
+	// Act
+	key, err := EncodeRecord([]string{"a", "b"}, map[string]any{"a": "x", "b": nil})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Encode("x", nil), key)
+}
@@ -0,0 +1,148 @@
+package lexkey
+
+import (
+	"math"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTrip encodes v between two fixed parts and decodes it back as type t, so variable-width
+// and self-delimited types are exercised away from the end of the key.
+func roundTrip(t *testing.T, v any, typ reflect.Type) any {
+	t.Helper()
+	key, err := NewLexKey("before", v, int64(-1))
+	require.NoError(t, err)
+	values, err := Decode(key, stringType, typ, int64Type)
+	require.NoError(t, err)
+	require.Equal(t, []any{"before", int64(-1)}, []any{values[0], values[2]})
+
+	last, err := NewLexKey("before", v)
+	require.NoError(t, err)
+	lastValues, err := Decode(last, stringType, typ)
+	require.NoError(t, err)
+	assert.Equal(t, Encode(values[1]), Encode(lastValues[1]), "decoding as the last part should agree")
+	return values[1]
+}
+
+func TestShouldRoundTripEverySupportedType(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{"nil", nil},
+		{"string", "hello, 世界"},
+		{"empty string", ""},
+		{"bytes", []byte{0x01, 0xFE}},
+		{"LexKey", LexKey{0x01, 0xFE}},
+		{"bool false", false},
+		{"bool true", true},
+		{"int", -42},
+		{"int8", int8(math.MinInt8)},
+		{"int16", int16(math.MaxInt16)},
+		{"int32", int32(math.MinInt32)},
+		{"int64 min", int64(math.MinInt64)},
+		{"int64 max", int64(math.MaxInt64)},
+		{"uint", uint(42)},
+		{"uint8", uint8(math.MaxUint8)},
+		{"uint16", uint16(math.MaxUint16)},
+		{"uint32", uint32(math.MaxUint32)},
+		{"uint64", uint64(math.MaxUint64)},
+		{"float32", float32(-1.25)},
+		{"float64", math.Pi},
+		{"float64 +Inf", math.Inf(1)},
+		{"float64 -Inf", math.Inf(-1)},
+		{"Float16", Float16(0xBC00)},
+		{"NonNeg", NonNeg(math.MaxInt64)},
+		{"TriBool", TriFalse},
+		{"Decimal", Decimal{Coefficient: -15, Exponent: -1}},
+		{"Decimal zero", Decimal{}},
+		{"Decimal large exponent", Decimal{Coefficient: 7, Exponent: 300}},
+		{"big.Rat", big.NewRat(-3, 8)},
+		{"big.Rat zero", new(big.Rat)},
+		{"uuid", uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")},
+		{"netip IPv4", netip.MustParseAddr("192.0.2.1")},
+		{"netip IPv6", netip.MustParseAddr("2001:db8::1")},
+		{"byte array", [4]byte{0x00, 0x01, 0xFE, 0xFF}},
+		{"time UTC", time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.UTC)},
+		{"time before epoch", time.Date(1901, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"duration", -90 * time.Second},
+		{"LengthPrefixed", LengthPrefixed("a\x00b")},
+		{"end sentinel", struct{}{}},
+		{"named int", testStatus(-3)},
+		{"named string", testName("alice")},
+		{"time.Month", time.March},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := roundTrip(t, tt.value, reflect.TypeOf(tt.value))
+
+			// Assert
+			assert.Equal(t, tt.value, got)
+		})
+	}
+}
+
+func TestShouldRoundTripNaNAsCanonicalNaN(t *testing.T) {
+	// Act
+	got := roundTrip(t, math.NaN(), reflect.TypeOf(0.0))
+
+	// Assert: NaN never equals itself, so check the class
+	assert.True(t, math.IsNaN(got.(float64)))
+}
+
+// Encodings that are lossy by design decode to a documented normal form.
+func TestShouldDecodeLossyTypesToTheirNormalForm(t *testing.T) {
+	zone := time.FixedZone("CEST", 2*60*60)
+	local := time.Date(2024, 5, 1, 14, 30, 0, 0, zone)
+	tests := []struct {
+		name     string
+		value    any
+		typ      reflect.Type
+		expected any
+	}{
+		{"time.Time decodes in UTC", local, reflect.TypeOf(time.Time{}), local.UTC()},
+		{"Decimal drops trailing zeros", Decimal{Coefficient: 150, Exponent: -2}, reflect.TypeOf(Decimal{}), Decimal{Coefficient: 15, Exponent: -1}},
+		{"big.Rat truncates to RatDigits", big.NewRat(1, 3), reflect.TypeOf(new(big.Rat)), func() any {
+			r, _ := new(big.Rat).SetString("0." + strings.Repeat("3", RatDigits))
+			return r
+		}()},
+		{"negative zero decodes as +0", math.Copysign(0, -1), reflect.TypeOf(0.0), 0.0},
+		{"CaseFold decodes folded", CaseFold("Straße"), reflect.TypeOf(""), "strasse"},
+		{"pointer decodes as its element", func() any { v := int64(7); return &v }(), int64Type, int64(7)},
+		{"invalid netip.Addr decodes as nil", netip.Addr{}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := roundTrip(t, tt.value, tt.typ)
+
+			// Assert
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestShouldKeepZonedTimeInstantAndOffset(t *testing.T) {
+	// Arrange
+	local := time.Date(2024, 5, 1, 14, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	// Act
+	got := time.Time(roundTrip(t, ZonedTime(local), reflect.TypeOf(ZonedTime{})).(ZonedTime))
+
+	// Assert: the zone name is not stored
+	_, offset := got.Zone()
+	assert.True(t, local.Equal(got))
+	assert.Equal(t, 2*60*60, offset)
+	assert.Equal(t, local.Format(time.DateTime), got.Format(time.DateTime))
+}
@@ -0,0 +1,102 @@
+package lexkey
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Field declares one named part of a Schema. A nil Type stands for a nil part, as in Decode.
+type Field struct {
+	Name string
+	Type reflect.Type
+}
+
+// Schema declares the named, typed parts of a key once, so encoding and decoding use the
+// same field order and widths and cannot drift apart.
+type Schema struct {
+	fields []Field
+	types  []reflect.Type
+}
+
+// NewSchema creates a Schema from fields in key order. Returns an error if there are no
+// fields, a name is empty or repeated, or a type cannot be decoded.
+func NewSchema(fields ...Field) (*Schema, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("cannot create Schema: no fields provided")
+	}
+	seen := make(map[string]bool, len(fields))
+	types := make([]reflect.Type, len(fields))
+	for i, f := range fields {
+		if f.Name == "" {
+			return nil, fmt.Errorf("cannot create Schema: field %d has no name", i)
+		}
+		if seen[f.Name] {
+			return nil, fmt.Errorf("cannot create Schema: duplicate field %q", f.Name)
+		}
+		seen[f.Name] = true
+		if _, _, err := partWidth(f.Type); err != nil {
+			return nil, fmt.Errorf("cannot create Schema: field %q: %w", f.Name, err)
+		}
+		types[i] = f.Type
+	}
+	return &Schema{fields: append([]Field(nil), fields...), types: types}, nil
+}
+
+// Fields returns a copy of the schema's fields in key order.
+func (s *Schema) Fields() []Field {
+	return append([]Field(nil), s.fields...)
+}
+
+// Types returns a copy of the field types in key order, for use with Decode and DecodeAt.
+func (s *Schema) Types() []reflect.Type {
+	return append([]reflect.Type(nil), s.types...)
+}
+
+// Encode builds a key from record, taking each field's value by name in schema order.
+// Every field must be present with exactly the declared type, and record must not contain
+// names outside the schema.
+func (s *Schema) Encode(record map[string]any) (LexKey, error) {
+	if len(record) != len(s.fields) {
+		for name := range record {
+			if !s.has(name) {
+				return nil, fmt.Errorf("cannot encode record: unknown field %q", name)
+			}
+		}
+	}
+	values := make([]any, len(s.fields))
+	for i, f := range s.fields {
+		v, ok := record[f.Name]
+		if !ok {
+			return nil, fmt.Errorf("cannot encode record: missing field %q", f.Name)
+		}
+		if reflect.TypeOf(v) != f.Type {
+			return nil, fmt.Errorf("cannot encode record: field %q: expected %v, got %T", f.Name, f.Type, v)
+		}
+		values[i] = v
+	}
+	return NewLexKey(values...)
+}
+
+// Decode decodes key into a record keyed by field name.
+func (s *Schema) Decode(key LexKey) (map[string]any, error) {
+	values, err := Decode(key, s.types...)
+	if err != nil {
+		return nil, err
+	}
+	record := make(map[string]any, len(values))
+	for i, f := range s.fields {
+		record[f.Name] = values[i]
+	}
+	return record, nil
+}
+
+// has reports whether the schema declares a field called name.
+func (s *Schema) has(name string) bool {
+	for _, f := range s.fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package lexkey
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSchema(t *testing.T) *Schema {
+	t.Helper()
+	s, err := NewSchema(
+		Field{Name: "tenant", Type: stringType},
+		Field{Name: "created", Type: reflect.TypeOf(time.Time{})},
+		Field{Name: "id", Type: int64Type},
+	)
+	require.NoError(t, err)
+	return s
+}
+
+func TestShouldRoundTripRecordThroughSchema(t *testing.T) {
+	// Arrange
+	s := newTestSchema(t)
+	record := map[string]any{
+		"tenant":  "acme",
+		"created": time.Unix(1700000000, 0).UTC(),
+		"id":      int64(42),
+	}
+
+	// Act
+	key, err := s.Encode(record)
+	require.NoError(t, err)
+	decoded, err := s.Decode(key)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, record, decoded)
+	assert.Equal(t, Encode("acme", time.Unix(1700000000, 0), int64(42)), key)
+}
+
+func TestShouldRejectInvalidRecords(t *testing.T) {
+	s := newTestSchema(t)
+	created := time.Unix(0, 0).UTC()
+	tests := []struct {
+		name   string
+		record map[string]any
+	}{
+		{"missing field", map[string]any{"tenant": "acme", "created": created}},
+		{"unknown field", map[string]any{"tenant": "acme", "created": created, "id": int64(1), "extra": 1}},
+		{"wrong type", map[string]any{"tenant": "acme", "created": created, "id": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := s.Encode(tt.record)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestShouldRejectInvalidSchemas(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []Field
+	}{
+		{"no fields", nil},
+		{"empty name", []Field{{Name: "", Type: stringType}}},
+		{"duplicate name", []Field{{Name: "a", Type: stringType}, {Name: "a", Type: int64Type}}},
+		{"unsupported type", []Field{{Name: "m", Type: reflect.TypeOf(map[string]int{})}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := NewSchema(tt.fields...)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestShouldExposeSchemaTypesForDecodeAt(t *testing.T) {
+	// Arrange
+	s := newTestSchema(t)
+	key, err := s.Encode(map[string]any{"tenant": "acme", "created": time.Unix(0, 0).UTC(), "id": int64(7)})
+	require.NoError(t, err)
+
+	// Act
+	id, err := DecodeAt[int64](key, 2, s.Types()...)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+	assert.Equal(t, "tenant", s.Fields()[0].Name)
+}
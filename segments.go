@@ -0,0 +1,63 @@
+package lexkey
+
+import (
+	"bytes"
+	"iter"
+)
+
+// Segments splits the key on Separator bytes into its raw segments. Leading, trailing and
+// adjacent separators produce empty segments; an empty key has no segments.
+// Segments alias the key's backing array.
+//
+// This is byte-level introspection only. LexKey does not escape data, so any 0x00 byte inside
+// a part (strings, byte slices, and most numeric encodings, e.g. int64 42 = 80 00 .. 00 2a)
+// also splits. Use a schema-aware decoder to recover typed parts.
+func (e LexKey) Segments() [][]byte {
+	if len(e) == 0 {
+		return nil
+	}
+	return bytes.Split(e, []byte{Separator})
+}
+
+// SegmentSeq returns an iterator over the same segments as Segments without allocating a slice.
+func (e LexKey) SegmentSeq() iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		if len(e) == 0 {
+			return
+		}
+		rest := []byte(e)
+		for {
+			i := bytes.IndexByte(rest, Separator)
+			if i < 0 {
+				yield(rest)
+				return
+			}
+			if !yield(rest[:i]) {
+				return
+			}
+			rest = rest[i+1:]
+		}
+	}
+}
+
+// CompareParts compares two keys segment by segment and reports the index of the first
+// differing segment with its comparison result (-1 or 1). A key that runs out of segments
+// first compares less. Returns (-1, 0) when the keys are equal.
+//
+// Segments are split like Segments, so for keys whose parts contain 0x00 bytes the index
+// counts raw segments rather than encoded parts. The sign always agrees with Compare(a, b).
+func CompareParts(a, b LexKey) (segmentIndex int, cmp int) {
+	if bytes.Equal(a, b) {
+		return -1, 0
+	}
+	as, bs := a.Segments(), b.Segments()
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := bytes.Compare(as[i], bs[i]); c != 0 {
+			return i, c
+		}
+	}
+	if len(as) < len(bs) {
+		return len(as), -1
+	}
+	return len(bs), 1
+}
@@ -0,0 +1,97 @@
+package lexkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldSplitKeyIntoSegments(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      LexKey
+		expected [][]byte
+	}{
+		{"empty key", LexKey{}, nil},
+		{"single segment", Encode("a"), [][]byte{[]byte("a")}},
+		{"string parts", Encode("tenant", "users", "alice"), [][]byte{[]byte("tenant"), []byte("users"), []byte("alice")}},
+		{"empty middle segment", Encode("a", "", "b"), [][]byte{[]byte("a"), {}, []byte("b")}},
+		{"leading separator", LexKey{Separator, 'a'}, [][]byte{{}, []byte("a")}},
+		{"trailing separator", EncodeFirst("a"), [][]byte{[]byte("a"), {}}},
+		{"only separator", LexKey{Separator}, [][]byte{{}, {}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := tt.key.Segments()
+
+			var seq [][]byte
+			for s := range tt.key.SegmentSeq() {
+				seq = append(seq, s)
+			}
+
+			// Assert
+			assert.Equal(t, tt.expected, got)
+			assert.Equal(t, tt.expected, seq)
+		})
+	}
+}
+
+func TestShouldSplitOnZeroBytesInsidePartsSinceDataIsNotEscaped(t *testing.T) {
+	// Arrange: the string part embeds a 0x00 byte
+	key := Encode("a\x00b", "c")
+
+	// Act
+	got := key.Segments()
+
+	// Assert
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b"), []byte("c")}, got)
+}
+
+func TestShouldStopSegmentIterationEarly(t *testing.T) {
+	// Arrange
+	key := Encode("a", "b", "c")
+
+	// Act
+	var got [][]byte
+	for s := range key.SegmentSeq() {
+		got = append(got, s)
+		if len(got) == 2 {
+			break
+		}
+	}
+
+	// Assert
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, got)
+}
+
+func TestShouldReportFirstDifferingPart(t *testing.T) {
+	tests := []struct {
+		name          string
+		a, b          LexKey
+		expectedIndex int
+		expectedCmp   int
+	}{
+		{"equal", Encode("a", "b"), Encode("a", "b"), -1, 0},
+		{"both empty", LexKey{}, LexKey{}, -1, 0},
+		{"first part differs", Encode("a", "z"), Encode("b", "a"), 0, -1},
+		{"second part differs", Encode("tenant", "users"), Encode("tenant", "orders"), 1, 1},
+		{"shorter key", Encode("a"), Encode("a", "b"), 1, -1},
+		{"longer key", Encode("a", "b", "c"), Encode("a", "b"), 2, 1},
+		{"prefix within segment", Encode("ab"), Encode("a", "c"), 0, 1},
+		{"empty versus non-empty", LexKey{}, Encode("a"), 0, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			index, cmp := CompareParts(tt.a, tt.b)
+
+			// Assert
+			assert.Equal(t, tt.expectedIndex, index)
+			assert.Equal(t, tt.expectedCmp, cmp)
+			assert.Equal(t, Compare(tt.a, tt.b), cmp)
+		})
+	}
+}
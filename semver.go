@@ -0,0 +1,111 @@
+package lexkey
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Semver identifier markers; the end of a prerelease sorts before any further identifier,
+// numeric identifiers before alphanumeric ones, and a release after every prerelease.
+const (
+	semverPrereleaseEnd = 0x00
+	semverNumericID     = 0x01
+	semverAlphaID       = 0x02
+	semverRelease       = 0xFF
+)
+
+// EncodeSemver parses a semantic version (semver.org 2.0.0, with an optional leading "v") and
+// encodes it so keys sort by version precedence: numerically by major, minor and patch
+// (1.2.0 < 1.10.0), with a prerelease before its release (1.0.0-rc.1 < 1.0.0), and prerelease
+// identifiers compared per the spec (1.0.0-alpha < 1.0.0-alpha.1 < 1.0.0-beta). Build
+// metadata (+build) does not affect precedence and is dropped, so 1.0.0+a and 1.0.0+b encode
+// identically.
+//
+// Returns an error for anything that is not a valid semantic version, including numeric
+// parts with leading zeros and empty identifiers.
+func EncodeSemver(v string) (LexKey, error) {
+	s := strings.TrimPrefix(v, "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		if err := checkSemverIdentifiers(s[i+1:], false); err != nil {
+			return nil, fmt.Errorf("cannot parse semver %q: build metadata: %w", v, err)
+		}
+		s = s[:i]
+	}
+	core, prerelease, hasPrerelease := strings.Cut(s, "-")
+	nums := strings.Split(core, ".")
+	if len(nums) != 3 {
+		return nil, fmt.Errorf("cannot parse semver %q: want MAJOR.MINOR.PATCH", v)
+	}
+	var out LexKey
+	for _, n := range nums {
+		u, err := parseSemverNumber(n)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse semver %q: %w", v, err)
+		}
+		out = append(out, EncodeUvarintSortable(u)...)
+	}
+	if !hasPrerelease {
+		return append(out, semverRelease), nil
+	}
+	if err := checkSemverIdentifiers(prerelease, true); err != nil {
+		return nil, fmt.Errorf("cannot parse semver %q: prerelease: %w", v, err)
+	}
+	for id := range strings.SplitSeq(prerelease, ".") {
+		if strings.Trim(id, "0123456789") == "" {
+			u, err := parseSemverNumber(id)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse semver %q: prerelease: %w", v, err)
+			}
+			out = append(out, semverNumericID)
+			out = append(out, EncodeUvarintSortable(u)...)
+			continue
+		}
+		out = append(out, semverAlphaID)
+		out = append(out, id...)
+		out = append(out, semverPrereleaseEnd)
+	}
+	return append(out, semverPrereleaseEnd), nil
+}
+
+// parseSemverNumber parses a numeric identifier, which must not have leading zeros.
+func parseSemverNumber(s string) (uint64, error) {
+	if s == "" {
+		return 0, errors.New("empty number")
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return 0, fmt.Errorf("number %q has a leading zero", s)
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, fmt.Errorf("invalid number %q", s)
+		}
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// checkSemverIdentifiers validates dot-separated identifiers of [0-9A-Za-z-]; in a
+// prerelease, numeric identifiers must not have leading zeros.
+func checkSemverIdentifiers(s string, prerelease bool) error {
+	for id := range strings.SplitSeq(s, ".") {
+		if id == "" {
+			return errors.New("empty identifier")
+		}
+		numeric := true
+		for i := 0; i < len(id); i++ {
+			c := id[i]
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+				numeric = false
+			default:
+				return fmt.Errorf("invalid character %q in identifier %q", c, id)
+			}
+		}
+		if prerelease && numeric && len(id) > 1 && id[0] == '0' {
+			return fmt.Errorf("numeric identifier %q has a leading zero", id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package lexkey
+
+import (
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldSortSemversByPrecedence(t *testing.T) {
+	// Arrange: ascending precedence, including the semver.org example chain
+	versions := []string{
+		"0.9.9",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.2.0",
+		"1.9.0",
+		"1.10.0",
+		"1.10.1",
+		"2.0.0",
+		"10.0.0",
+	}
+
+	// Act / Assert
+	for i := 1; i < len(versions); i++ {
+		prev, err := EncodeSemver(versions[i-1])
+		require.NoError(t, err)
+		next, err := EncodeSemver(versions[i])
+		require.NoError(t, err)
+		assert.Negative(t, Compare(prev, next), "%s should sort before %s", versions[i-1], versions[i])
+	}
+}
+
+func TestShouldIgnoreBuildMetadataAndVPrefix(t *testing.T) {
+	// Arrange
+	plain, err := EncodeSemver("1.2.3")
+	require.NoError(t, err)
+
+	// Act
+	withBuild, errBuild := EncodeSemver("1.2.3+build.7")
+	withV, errV := EncodeSemver("v1.2.3")
+
+	// Assert
+	require.NoError(t, errBuild)
+	require.NoError(t, errV)
+	assert.Equal(t, plain, withBuild)
+	assert.Equal(t, plain, withV)
+	test.AssertHexEqual(t, "010203ff", plain)
+}
+
+func TestShouldRejectInvalidSemvers(t *testing.T) {
+	tests := []string{
+		"",
+		"1.2",
+		"1.2.3.4",
+		"01.2.3",
+		"1.2.x",
+		"1.2.3-",
+		"1.2.3-alpha..1",
+		"1.2.3-01",
+		"1.2.3-al_pha",
+		"1.2.3+",
+		"1.2.3-99999999999999999999",
+	}
+
+	for _, v := range tests {
+		t.Run(v, func(t *testing.T) {
+			// Act
+			_, err := EncodeSemver(v)
+
+			// Assert
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "cannot parse semver")
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package lexkey
+
+import "slices"
+
+// EncodeSet encodes a set of strings in canonical form: the elements are sorted and
+// deduplicated, then encoded as separate parts, so sets with the same members produce the
+// same key regardless of order or repeats: {"b", "a", "a"} and {"a", "b"} both encode as
+// Encode("a", "b"). The empty set encodes as the empty key. elems is not modified.
+//
+// As with any string parts, elements containing 0x00 bytes make the boundaries ambiguous;
+// see EncodeCString.
+func EncodeSet(elems []string) LexKey {
+	if len(elems) == 0 {
+		return LexKey{}
+	}
+	sorted := slices.Clone(elems)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+	parts := make([]any, len(sorted))
+	for i, e := range sorted {
+		parts[i] = e
+	}
+	return Encode(parts...)
+}
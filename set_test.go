@@ -0,0 +1,43 @@
+package lexkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldEncodeEqualSetsIdentically(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+	}{
+		{"duplicates and order", []string{"b", "a", "a"}, []string{"a", "b"}},
+		{"reversed", []string{"z", "m", "a"}, []string{"a", "m", "z"}},
+		{"single", []string{"x", "x", "x"}, []string{"x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Assert
+			assert.Equal(t, EncodeSet(tt.a), EncodeSet(tt.b))
+		})
+	}
+	assert.Equal(t, Encode("a", "b"), EncodeSet([]string{"b", "a", "a"}))
+}
+
+func TestShouldNotModifyInputOfEncodeSet(t *testing.T) {
+	// Arrange
+	elems := []string{"b", "a", "a"}
+
+	// Act
+	_ = EncodeSet(elems)
+
+	// Assert
+	assert.Equal(t, []string{"b", "a", "a"}, elems)
+}
+
+func TestShouldEncodeEmptySetAsEmptyKey(t *testing.T) {
+	// Assert
+	assert.Equal(t, LexKey{}, EncodeSet(nil))
+	assert.NotEqual(t, EncodeSet([]string{"a"}), EncodeSet([]string{"a", "b"}))
+}
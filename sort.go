@@ -0,0 +1,49 @@
+package lexkey
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Sort sorts keys in ascending lexicographic order using byte-wise comparison.
+func Sort(keys []LexKey) {
+	slices.SortFunc(keys, Compare)
+}
+
+// SortStable sorts keys in ascending lexicographic order, keeping equal keys in their original order.
+func SortStable(keys []LexKey) {
+	slices.SortStableFunc(keys, Compare)
+}
+
+// SearchInsert returns the index at which target should be inserted into the sorted keys
+// to keep them sorted. If target is already present, the index of the first match is returned.
+func SearchInsert(keys []LexKey, target LexKey) int {
+	i, _ := slices.BinarySearchFunc(keys, target, Compare)
+	return i
+}
+
+// Less reports whether a sorts before b. It matches the less-function signature used by
+// generic ordered containers, e.g. btree.NewG[lexkey.LexKey](32, lexkey.Less) with
+// github.com/google/btree; use Compare where a three-way comparator is expected.
+func Less(a, b LexKey) bool {
+	return Compare(a, b) < 0
+}
+
+// VerifyOrdering checks that enc preserves the order of values, which must already be sorted
+// ascending: each encoded key must sort at or after the key of the value before it. Equal
+// values may encode equally, so only out-of-order pairs are reported. It is meant for
+// self-testing custom encoders in unit tests, e.g.
+//
+//	err := lexkey.VerifyOrdering(sortedPrices, func(p Price) lexkey.LexKey { return lexkey.Encode(p.Cents) })
+func VerifyOrdering[T any](values []T, enc func(T) LexKey) error {
+	var prev LexKey
+	for i, v := range values {
+		key := enc(v)
+		if i > 0 && Compare(prev, key) > 0 {
+			return fmt.Errorf("VerifyOrdering: value %d (%v) encodes to %s, before value %d (%v) at %s",
+				i, v, key.ToHexString(), i-1, values[i-1], prev.ToHexString())
+		}
+		prev = key
+	}
+	return nil
+}
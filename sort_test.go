@@ -0,0 +1,137 @@
+package lexkey
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldSortMixedTypeKeysInEncodingOrder(t *testing.T) {
+	// Arrange
+	expected := []LexKey{
+		Encode("user", -100),
+		Encode("user", 0),
+		Encode("user", 5),
+		Encode("user", 5, time.Unix(0, 0)),
+		Encode("user", 5, time.Unix(1700000000, 0)),
+		Encode("user", 50),
+		Encode("users"),
+	}
+	keys := []LexKey{expected[5], expected[2], expected[6], expected[0], expected[4], expected[1], expected[3]}
+
+	// Act
+	Sort(keys)
+
+	// Assert
+	assert.Equal(t, expected, keys)
+}
+
+func TestShouldSortStableKeepingEqualKeysInOrder(t *testing.T) {
+	// Arrange
+	first := Encode("a")
+	second := Encode("a")
+	keys := []LexKey{Encode("b"), first, second}
+
+	// Act
+	SortStable(keys)
+
+	// Assert
+	assert.Equal(t, Encode("b"), keys[2])
+	assert.Same(t, &first[0], &keys[0][0])
+	assert.Same(t, &second[0], &keys[1][0])
+}
+
+func TestShouldSearchInsertPosition(t *testing.T) {
+	// Arrange
+	keys := []LexKey{Encode(-10), Encode(0), Encode(10), Encode(20)}
+
+	tests := []struct {
+		name     string
+		target   LexKey
+		expected int
+	}{
+		{"before all", Encode(-20), 0},
+		{"existing", Encode(10), 2},
+		{"between", Encode(15), 3},
+		{"after all", Encode(30), 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := SearchInsert(keys, tt.target)
+
+			// Assert
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestShouldSearchInsertIntoEmptySlice(t *testing.T) {
+	// Act / Assert
+	assert.Equal(t, 0, SearchInsert(nil, Encode("a")))
+}
+
+func TestShouldReportLessConsistentWithCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     LexKey
+		expected bool
+	}{
+		{"smaller", Encode("a"), Encode("b"), true},
+		{"equal", Encode("a"), Encode("a"), false},
+		{"greater", Encode("b"), Encode("a"), false},
+		{"prefix", Encode("a"), Encode("a", "b"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act / Assert
+			assert.Equal(t, tt.expected, Less(tt.a, tt.b))
+		})
+	}
+}
+
+func TestShouldVerifyOrderingOfBuiltInNumericEncoders(t *testing.T) {
+	// Arrange
+	ints := []int64{math.MinInt64, -1000, -1, 0, 1, 1000, math.MaxInt64}
+	floats := []float64{math.Inf(-1), -1e300, -1.5, -math.SmallestNonzeroFloat64, 0, 1e-300, 2.5, math.Inf(1)}
+	uints := []uint32{0, 1, 255, 256, math.MaxUint32}
+
+	// Act
+	intErr := VerifyOrdering(ints, func(v int64) LexKey { return Encode(v) })
+	floatErr := VerifyOrdering(floats, func(v float64) LexKey { return Encode(v) })
+	uintErr := VerifyOrdering(uints, func(v uint32) LexKey { return Encode(v) })
+
+	// Assert
+	assert.NoError(t, intErr)
+	assert.NoError(t, floatErr)
+	assert.NoError(t, uintErr)
+}
+
+func TestShouldReportOrderViolationFromVerifyOrdering(t *testing.T) {
+	// Arrange: little-endian bytes do not preserve order
+	values := []uint16{1, 256}
+	littleEndian := func(v uint16) LexKey { return LexKey{byte(v), byte(v >> 8)} }
+
+	// Act
+	err := VerifyOrdering(values, littleEndian)
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "value 1 (256)")
+}
+
+func TestShouldAcceptEqualEncodingsInVerifyOrdering(t *testing.T) {
+	// Arrange
+	values := []string{"a", "A", "b"}
+
+	// Act
+	err := VerifyOrdering(values, func(s string) LexKey { return Encode(CaseFold(s)) })
+
+	// Assert
+	assert.NoError(t, err)
+}
@@ -0,0 +1,53 @@
+package lexkey
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+)
+
+// sqlNullValue unwraps the database/sql nullable column types: a Null that is not Valid
+// encodes like nil (the Separator byte, which sorts before any other value in the same
+// position), and a Valid one like its underlying value.
+func sqlNullValue(v any) (any, bool) {
+	switch x := v.(type) {
+	case sql.NullString:
+		return sqlNullOr(x.Valid, x.String), true
+	case sql.NullInt64:
+		return sqlNullOr(x.Valid, x.Int64), true
+	case sql.NullInt32:
+		return sqlNullOr(x.Valid, x.Int32), true
+	case sql.NullInt16:
+		return sqlNullOr(x.Valid, x.Int16), true
+	case sql.NullByte:
+		return sqlNullOr(x.Valid, x.Byte), true
+	case sql.NullFloat64:
+		return sqlNullOr(x.Valid, x.Float64), true
+	case sql.NullBool:
+		return sqlNullOr(x.Valid, x.Bool), true
+	case sql.NullTime:
+		return sqlNullOr(x.Valid, x.Time), true
+	}
+	return nil, false
+}
+
+// sqlGenericNullValue unwraps the generic sql.Null[T], which cannot be matched by a type
+// switch, by its package, name and V/Valid fields.
+func sqlGenericNullValue(rv reflect.Value) (any, bool) {
+	t := rv.Type()
+	if t.PkgPath() != "database/sql" || !strings.HasPrefix(t.Name(), "Null[") {
+		return nil, false
+	}
+	valid, value := rv.FieldByName("Valid"), rv.FieldByName("V")
+	if !valid.IsValid() || valid.Kind() != reflect.Bool || !value.IsValid() {
+		return nil, false
+	}
+	return sqlNullOr(valid.Bool(), value.Interface()), true
+}
+
+func sqlNullOr(valid bool, v any) any {
+	if !valid {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,72 @@
+package lexkey
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldEncodeSQLNullTypes(t *testing.T) {
+	at := time.Unix(1700000000, 0).UTC()
+	tests := []struct {
+		name  string
+		valid any
+		value any
+		null  any
+	}{
+		{"NullString", sql.NullString{String: "a", Valid: true}, "a", sql.NullString{}},
+		{"NullInt64", sql.NullInt64{Int64: -7, Valid: true}, int64(-7), sql.NullInt64{}},
+		{"NullInt32", sql.NullInt32{Int32: 7, Valid: true}, int32(7), sql.NullInt32{}},
+		{"NullInt16", sql.NullInt16{Int16: 7, Valid: true}, int16(7), sql.NullInt16{}},
+		{"NullByte", sql.NullByte{Byte: 7, Valid: true}, uint8(7), sql.NullByte{}},
+		{"NullFloat64", sql.NullFloat64{Float64: 1.5, Valid: true}, 1.5, sql.NullFloat64{}},
+		{"NullBool", sql.NullBool{Bool: true, Valid: true}, true, sql.NullBool{}},
+		{"NullTime", sql.NullTime{Time: at, Valid: true}, at, sql.NullTime{}},
+		{"generic Null", sql.Null[int64]{V: 3, Valid: true}, int64(3), sql.Null[int64]{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			valid, errValid := NewLexKey("col", tt.valid)
+			null, errNull := NewLexKey("col", tt.null)
+
+			// Assert
+			require.NoError(t, errValid)
+			require.NoError(t, errNull)
+			assert.Equal(t, Encode("col", tt.value), valid)
+			assert.Equal(t, Encode("col", nil), null)
+			assert.Negative(t, Compare(null, valid), "null should sort before a value")
+		})
+	}
+}
+
+func TestShouldSortNullBeforeAllNonEmptyValues(t *testing.T) {
+	// Arrange
+	null := Encode(sql.NullInt64{})
+	values := []LexKey{
+		Encode(sql.NullInt64{Int64: -1 << 63, Valid: true}),
+		Encode(sql.NullInt64{Int64: 0, Valid: true}),
+		Encode(sql.NullFloat64{Float64: -1e308, Valid: true}),
+	}
+
+	// Act / Assert
+	for _, v := range values {
+		assert.Negative(t, Compare(null, v))
+	}
+}
+
+func TestShouldAcceptSQLNullTypesInStrictMode(t *testing.T) {
+	// Arrange
+	enc := DefaultEncoder()
+	enc.Strict = true
+
+	// Act
+	_, err := enc.NewLexKey(sql.NullString{String: "a", Valid: true}, sql.NullInt64{})
+
+	// Assert
+	require.NoError(t, err)
+}
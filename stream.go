@@ -0,0 +1,101 @@
+package lexkey
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Stream framing: each key is written as its length in a 4-byte big-endian uint32 followed
+// by the key bytes, so keys of any content (including 0x00 bytes and empty keys) can be
+// stored back to back in a dump file or pipe.
+
+// DefaultMaxFrameLen is the longest frame a Decoder accepts when its MaxFrameLen is zero.
+const DefaultMaxFrameLen = 1 << 20
+
+// StreamEncoder writes length-framed keys to an io.Writer for reading back with a Decoder.
+// Writes are not buffered; wrap the writer in a bufio.Writer for large dumps.
+type StreamEncoder struct {
+	w   io.Writer
+	buf [lengthPrefixSize]byte
+}
+
+// NewStreamEncoder returns a StreamEncoder writing to w.
+func NewStreamEncoder(w io.Writer) *StreamEncoder {
+	return &StreamEncoder{w: w}
+}
+
+// WriteKey writes a single framed key.
+func (e *StreamEncoder) WriteKey(key LexKey) error {
+	if err := checkLengthPrefix(len(key)); err != nil {
+		return fmt.Errorf("cannot write LexKey: %w", err)
+	}
+	binary.BigEndian.PutUint32(e.buf[:], uint32(len(key)))
+	if _, err := e.w.Write(e.buf[:]); err != nil {
+		return err
+	}
+	_, err := e.w.Write(key)
+	return err
+}
+
+// Encode encodes parts with NewLexKey and writes the resulting framed key.
+func (e *StreamEncoder) Encode(parts ...any) error {
+	key, err := NewLexKey(parts...)
+	if err != nil {
+		return err
+	}
+	return e.WriteKey(key)
+}
+
+// Decoder reads length-framed keys written by a StreamEncoder from an io.Reader.
+// Reads are not buffered; wrap the reader in a bufio.Reader for large dumps.
+type Decoder struct {
+	MaxFrameLen int // Reject frames longer than this many bytes with ErrFrameTooLong; 0 means DefaultMaxFrameLen
+
+	r      io.Reader
+	schema []reflect.Type
+	buf    [lengthPrefixSize]byte
+}
+
+// NewDecoder returns a Decoder reading from r that decodes each key with schema (see Decode).
+// The schema may be omitted when only NextKey is used.
+func NewDecoder(r io.Reader, schema ...reflect.Type) *Decoder {
+	return &Decoder{r: r, schema: schema}
+}
+
+// NextKey reads the next framed key without decoding it. It returns io.EOF once the stream
+// ends cleanly between keys, and io.ErrUnexpectedEOF if it ends inside a key. Frames longer
+// than MaxFrameLen are rejected before any key bytes are allocated or read.
+func (d *Decoder) NextKey() (LexKey, error) {
+	if _, err := io.ReadFull(d.r, d.buf[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(d.buf[:])
+	limit := d.MaxFrameLen
+	if limit <= 0 {
+		limit = DefaultMaxFrameLen
+	}
+	if uint64(n) > uint64(limit) {
+		return nil, fmt.Errorf("cannot read LexKey: frame of %d bytes exceeds %d: %w", n, limit, ErrFrameTooLong)
+	}
+	key := make(LexKey, n)
+	if _, err := io.ReadFull(d.r, key); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return key, nil
+}
+
+// Next reads the next framed key and decodes it with the Decoder's schema.
+// It returns io.EOF once the stream ends cleanly between keys.
+func (d *Decoder) Next() ([]any, error) {
+	key, err := d.NextKey()
+	if err != nil {
+		return nil, err
+	}
+	return Decode(key, d.schema...)
+}
@@ -0,0 +1,148 @@
+package lexkey
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldRoundTripKeysThroughStream(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	enc := NewStreamEncoder(&buf)
+	require.NoError(t, enc.Encode("alice", int64(1)))
+	require.NoError(t, enc.Encode("", int64(0)))
+	require.NoError(t, enc.Encode("bob", int64(-2)))
+	dec := NewDecoder(&buf, stringType, int64Type)
+
+	// Act
+	var got [][]any
+	for {
+		values, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		got = append(got, values)
+	}
+
+	// Assert
+	assert.Equal(t, [][]any{{"alice", int64(1)}, {"", int64(0)}, {"bob", int64(-2)}}, got)
+}
+
+func TestShouldRoundTripEmptyAndZeroByteKeysThroughStream(t *testing.T) {
+	// Arrange
+	keys := []LexKey{Encode("a"), {}, {0x00, 0x00}, Encode("z")}
+	var buf bytes.Buffer
+	enc := NewStreamEncoder(&buf)
+	for _, k := range keys {
+		require.NoError(t, enc.WriteKey(k))
+	}
+	dec := NewDecoder(&buf)
+
+	// Act
+	var got []LexKey
+	for {
+		k, err := dec.NextKey()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		got = append(got, k)
+	}
+
+	// Assert
+	assert.Equal(t, keys, got)
+}
+
+func TestShouldFrameKeysWithBigEndianLength(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	require.NoError(t, NewStreamEncoder(&buf).WriteKey(Encode("ab")))
+
+	// Assert
+	test.AssertHexEqual(t, "000000026162", buf.Bytes())
+}
+
+func TestShouldReportUnexpectedEOFForTruncatedStream(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"truncated length", []byte{0x00, 0x00}},
+		{"truncated key", []byte{0x00, 0x00, 0x00, 0x03, 'a'}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			dec := NewDecoder(bytes.NewReader(tt.data))
+
+			// Act
+			_, err := dec.NextKey()
+
+			// Assert
+			assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		})
+	}
+}
+
+func TestShouldReturnDecodeErrorsFromStream(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	require.NoError(t, NewStreamEncoder(&buf).Encode("a"))
+	dec := NewDecoder(&buf, reflect.TypeOf(int64(0)))
+
+	// Act
+	_, err := dec.Next()
+
+	// Assert
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, io.EOF)
+}
+
+func TestShouldRejectFramesLongerThanMaxFrameLen(t *testing.T) {
+	tests := []struct {
+		name string
+		max  int
+		data []byte
+	}{
+		{"maximum uint32 length", 0, []byte{0xff, 0xff, 0xff, 0xff, 'a', 'b', 'c'}},
+		{"just over default", 0, []byte{0x00, 0x10, 0x00, 0x01, 'a'}},
+		{"just over custom", 2, []byte{0x00, 0x00, 0x00, 0x03, 'a', 'b', 'c'}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			dec := NewDecoder(bytes.NewReader(tt.data))
+			dec.MaxFrameLen = tt.max
+
+			// Act
+			_, err := dec.NextKey()
+
+			// Assert
+			assert.ErrorIs(t, err, ErrFrameTooLong)
+		})
+	}
+}
+
+func TestShouldAcceptFrameAtMaxFrameLen(t *testing.T) {
+	// Arrange
+	dec := NewDecoder(bytes.NewReader([]byte{0x00, 0x00, 0x00, 0x02, 'a', 'b'}))
+	dec.MaxFrameLen = 2
+
+	// Act
+	key, err := dec.NextKey()
+
+	// Assert
+	require.NoError(t, err)
+	test.AssertHexEqual(t, "6162", key)
+}
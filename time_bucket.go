@@ -0,0 +1,18 @@
+package lexkey
+
+import "time"
+
+// EncodeTimeBucket encodes the start of the bucket containing t, so every time in the same
+// bucket (e.g. a 10-second window) produces the same key: Encode(metricID) followed by
+// EncodeTimeBucket(t, 10*time.Second) groups a window's points under one prefix. Buckets are
+// aligned to the Unix epoch and floored, so times before 1970 fall in the bucket that starts
+// at or before them. The result equals Encode of the bucket start as a time.Time, so bucket
+// keys sort chronologically and decode as times. A bucket <= 0 is treated as time.Nanosecond.
+// Like time.Time parts, t must lie within the years 1678 to 2262.
+func EncodeTimeBucket(t time.Time, bucket time.Duration) LexKey {
+	if bucket <= 0 {
+		bucket = time.Nanosecond
+	}
+	start := floorBucket(time.Duration(t.UnixNano()), bucket) * bucket
+	return Encode(time.Unix(0, int64(start)))
+}
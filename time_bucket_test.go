@@ -0,0 +1,65 @@
+package lexkey
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldShareKeyForTimesInSameBucket(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 5, 1, 12, 0, 10, 0, time.UTC)
+	times := []time.Time{start, start.Add(time.Nanosecond), start.Add(9 * time.Second), start.Add(10*time.Second - 1)}
+
+	// Act / Assert
+	for _, ts := range times {
+		assert.Equal(t, Encode(start), EncodeTimeBucket(ts, 10*time.Second), "time %v", ts)
+	}
+}
+
+func TestShouldSortTimeBucketsChronologically(t *testing.T) {
+	// Arrange
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	times := []time.Time{
+		time.Unix(-11, 0),
+		time.Unix(-1, 0),
+		time.Unix(0, 0),
+		base.Add(-time.Nanosecond),
+		base,
+		base.Add(10 * time.Second),
+	}
+
+	// Act / Assert
+	for i := 1; i < len(times); i++ {
+		prev, next := EncodeTimeBucket(times[i-1], 10*time.Second), EncodeTimeBucket(times[i], 10*time.Second)
+		assert.Negative(t, Compare(prev, next), "%v should sort before %v", times[i-1], times[i])
+	}
+}
+
+func TestShouldFloorTimesBeforeEpochToBucketStart(t *testing.T) {
+	// Arrange: -1s lies in the bucket [-10s, 0s), not [0s, 10s)
+	ts := time.Unix(-1, 0)
+
+	// Act
+	key := EncodeTimeBucket(ts, 10*time.Second)
+
+	// Assert
+	values, err := Decode(key, reflect.TypeOf(time.Time{}))
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(-10, 0).UTC(), values[0])
+}
+
+func TestShouldSupportSubSecondTimeBuckets(t *testing.T) {
+	// Arrange
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 123456789, time.UTC)
+
+	// Act
+	key := EncodeTimeBucket(ts, 100*time.Millisecond)
+
+	// Assert
+	assert.Equal(t, Encode(time.Date(2024, 5, 1, 12, 0, 0, 100000000, time.UTC)), key)
+	assert.Equal(t, Encode(ts), EncodeTimeBucket(ts, 0))
+}
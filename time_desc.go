@@ -0,0 +1,16 @@
+package lexkey
+
+import "time"
+
+// EncodeTimeDesc encodes t so that later times sort first, for "newest first" feeds scanned
+// forward: it is the bitwise NOT of the 8-byte time.Time encoding, which reverses its order
+// exactly over the whole UnixNano range. Use it as the part after the feed's prefix, e.g.
+// Concat(Encode("feed", userID), EncodeTimeDesc(postedAt)). Like time.Time parts, t is
+// reduced to UnixNano in UTC, so it must lie within the years 1678 to 2262.
+func EncodeTimeDesc(t time.Time) LexKey {
+	key := Encode(t)
+	for i := range key {
+		key[i] = ^key[i]
+	}
+	return key
+}
@@ -0,0 +1,48 @@
+package lexkey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldSortLaterTimesFirstWithEncodeTimeDesc(t *testing.T) {
+	// Arrange: ascending times, including before the epoch and nanosecond neighbours
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	times := []time.Time{
+		time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Unix(-1, 0),
+		time.Unix(0, 0),
+		base,
+		base.Add(time.Nanosecond),
+		base.Add(time.Hour),
+	}
+
+	// Act / Assert
+	for i := 1; i < len(times); i++ {
+		later, earlier := EncodeTimeDesc(times[i]), EncodeTimeDesc(times[i-1])
+		assert.Negative(t, Compare(later, earlier), "%v should sort before %v", times[i], times[i-1])
+	}
+}
+
+func TestShouldInvertTimeEncodingWithEncodeTimeDesc(t *testing.T) {
+	// Arrange
+	ts := time.Unix(1700000000, 0)
+
+	// Act
+	key := EncodeTimeDesc(ts)
+
+	// Assert: the complement of 97 97 9c fe 36 2a 00 00
+	test.AssertHexEqual(t, "68686301c9d5ffff", key)
+}
+
+func TestShouldIgnoreTimeZoneInEncodeTimeDesc(t *testing.T) {
+	// Arrange
+	loc := time.FixedZone("UTC-4", -4*60*60)
+	utc := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	// Act / Assert
+	assert.Equal(t, EncodeTimeDesc(utc), EncodeTimeDesc(utc.In(loc)))
+}
@@ -0,0 +1,16 @@
+package lexkey
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// EncodeTimeSeq encodes t followed by a sequence number so events recorded at the same
+// instant still get distinct keys that sort deterministically: by time, then by seq. The key is
+// the 8-byte time.Time encoding and seq as 4 big-endian bytes, with no Separator between them.
+// Like time.Time parts, t is reduced to UnixNano in UTC (its monotonic clock reading is
+// ignored), so keys only increase if the caller's clock does: seq breaks ties, it does not
+// repair a wall clock that steps backwards. Go's time.Time has no leap seconds to collide on.
+func EncodeTimeSeq(t time.Time, seq uint32) LexKey {
+	return binary.BigEndian.AppendUint32(Encode(t), seq)
+}
@@ -0,0 +1,49 @@
+package lexkey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldOrderSameInstantBySequenceWithEncodeTimeSeq(t *testing.T) {
+	// Arrange
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	// Act
+	first, second := EncodeTimeSeq(ts, 1), EncodeTimeSeq(ts, 2)
+
+	// Assert
+	assert.Negative(t, Compare(first, second))
+}
+
+func TestShouldOrderByTimeBeforeSequenceWithEncodeTimeSeq(t *testing.T) {
+	// Arrange
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	keys := []LexKey{
+		EncodeTimeSeq(time.Unix(-1, 0), 0),
+		EncodeTimeSeq(ts, 0),
+		EncodeTimeSeq(ts, 1),
+		EncodeTimeSeq(ts, 256),
+		EncodeTimeSeq(ts, ^uint32(0)),
+		EncodeTimeSeq(ts.Add(time.Nanosecond), 0),
+	}
+
+	// Act / Assert
+	for i := 1; i < len(keys); i++ {
+		assert.Negative(t, Compare(keys[i-1], keys[i]), "key %d should sort before key %d", i-1, i)
+	}
+}
+
+func TestShouldAppendSequenceToTimeEncoding(t *testing.T) {
+	// Arrange
+	ts := time.Unix(1700000000, 0).In(time.FixedZone("UTC+2", 2*60*60))
+
+	// Act
+	key := EncodeTimeSeq(ts, 0x0102)
+
+	// Assert
+	test.AssertHexEqual(t, "97979cfe362a000000000102", key)
+}
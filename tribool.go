@@ -0,0 +1,64 @@
+package lexkey
+
+import "fmt"
+
+// TriBool is a three-state boolean for nullable boolean columns. It encodes as a single byte
+// that sorts TriUnknown (0x00) < TriFalse (0x01) < TriTrue (0x02), so an unknown value no
+// longer shares false's encoding the way a nil part and false do. TriUnknown still encodes
+// like nil; declare TriBool in the decode schema to read the part back.
+type TriBool uint8
+
+const (
+	// TriUnknown is the zero value: the boolean is null or not known.
+	TriUnknown TriBool = iota
+	// TriFalse is a known false.
+	TriFalse
+	// TriTrue is a known true.
+	TriTrue
+)
+
+// TriBoolOf returns TriUnknown for a nil b, otherwise TriTrue or TriFalse.
+func TriBoolOf(b *bool) TriBool {
+	switch {
+	case b == nil:
+		return TriUnknown
+	case *b:
+		return TriTrue
+	}
+	return TriFalse
+}
+
+// Bool returns the boolean value and whether it is known.
+func (b TriBool) Bool() (value, known bool) {
+	return b == TriTrue, b != TriUnknown
+}
+
+// String returns "unknown", "false" or "true".
+func (b TriBool) String() string {
+	switch b {
+	case TriUnknown:
+		return "unknown"
+	case TriFalse:
+		return "false"
+	case TriTrue:
+		return "true"
+	}
+	return fmt.Sprintf("TriBool(%d)", uint8(b))
+}
+
+// encodeTriBool writes b as its single byte, rejecting values outside the three states.
+func encodeTriBool(dst []byte, b TriBool) (int, error) {
+	if b > TriTrue {
+		return 0, fmt.Errorf("invalid TriBool value %d", uint8(b))
+	}
+	dst[0] = byte(b)
+	return 1, nil
+}
+
+// decodeTriBool reverses encodeTriBool.
+func decodeTriBool(seg []byte) (TriBool, error) {
+	if seg[0] > byte(TriTrue) {
+		return TriUnknown, fmt.Errorf("invalid TriBool byte 0x%02x", seg[0])
+	}
+	return TriBool(seg[0]), nil
+}
@@ -0,0 +1,73 @@
+package lexkey
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldSortTriBoolUnknownFalseTrue(t *testing.T) {
+	// Arrange
+	keys := []LexKey{Encode("flag", TriTrue), Encode("flag", TriUnknown), Encode("flag", TriFalse)}
+
+	// Act
+	Sort(keys)
+
+	// Assert
+	assert.Equal(t, []LexKey{Encode("flag", TriUnknown), Encode("flag", TriFalse), Encode("flag", TriTrue)}, keys)
+	test.AssertHexEqual(t, "00", Encode(TriUnknown))
+	test.AssertHexEqual(t, "01", Encode(TriFalse))
+	test.AssertHexEqual(t, "02", Encode(TriTrue))
+}
+
+func TestShouldKeepTriBoolUnknownDistinctFromFalse(t *testing.T) {
+	// Act / Assert: plain bool false collides with nil; TriBool keeps them apart
+	assert.Equal(t, Encode(nil), Encode(false))
+	assert.NotEqual(t, Encode(TriUnknown), Encode(TriFalse))
+}
+
+func TestShouldDecodeTriBoolStates(t *testing.T) {
+	triBoolType := reflect.TypeOf(TriBool(0))
+	for _, want := range []TriBool{TriUnknown, TriFalse, TriTrue} {
+		t.Run(want.String(), func(t *testing.T) {
+			// Arrange
+			key := Encode("flag", want, int64(1))
+
+			// Act
+			values, err := Decode(key, stringType, triBoolType, int64Type)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, []any{"flag", want, int64(1)}, values)
+		})
+	}
+}
+
+func TestShouldRejectInvalidTriBool(t *testing.T) {
+	// Act
+	_, encErr := NewLexKey(TriBool(3))
+	_, decErr := Decode(LexKey{0x03}, reflect.TypeOf(TriBool(0)))
+
+	// Assert
+	require.Error(t, encErr)
+	require.Error(t, decErr)
+	assert.Contains(t, encErr.Error(), "invalid TriBool value 3")
+}
+
+func TestShouldConvertBetweenTriBoolAndBool(t *testing.T) {
+	// Arrange
+	yes, no := true, false
+
+	// Act / Assert
+	assert.Equal(t, TriUnknown, TriBoolOf(nil))
+	assert.Equal(t, TriTrue, TriBoolOf(&yes))
+	assert.Equal(t, TriFalse, TriBoolOf(&no))
+	value, known := TriTrue.Bool()
+	assert.True(t, value)
+	assert.True(t, known)
+	_, known = TriUnknown.Bool()
+	assert.False(t, known)
+}
@@ -0,0 +1,73 @@
+package lexkey
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Type tags written before each part by an Encoder with TypeTags set. With tags, parts of
+// different types never share an encoding, and sort by type first in this order:
+// nil < bool < signed integers (and time.Duration) < unsigned integers < float64 < Float16 <
+// Decimal < time.Time < ZonedTime < uuid.UUID < string < []byte and LexKey < LengthPrefixed <
+// TriBool.
+// So for one position: nil < false < true < 0 < "" < "a". json.Number parts take the tag of
+// the number they resolve to. struct{} is written as the bare EndMarker so it still sorts
+// after every tagged part.
+//
+// Untagged keys (the default) keep the original format, where "", nil and false can collide:
+// Encode("", x) and Encode(nil, x) differ only by an extra byte, and Encode(false) ==
+// Encode(nil). Tagged keys are a different format; Decode does not read them, and they must
+// not be compared with untagged keys.
+const (
+	tagNil byte = 0x10 + iota
+	tagBool
+	tagInt
+	tagUint
+	tagFloat
+	tagFloat16
+	tagDecimal
+	tagTime
+	tagZonedTime
+	tagUUID
+	tagString
+	tagBytes
+	tagLengthPrefixed
+	tagTriBool
+)
+
+// typeTag returns the tag for a canonicalized part, or false if the part is written untagged
+// (struct{}), resolved before tagging (json numbers), or unsupported.
+func typeTag(v any) (byte, bool) {
+	switch v.(type) {
+	case nil:
+		return tagNil, true
+	case bool:
+		return tagBool, true
+	case int64, time.Duration:
+		return tagInt, true
+	case uint64:
+		return tagUint, true
+	case float64:
+		return tagFloat, true
+	case Float16:
+		return tagFloat16, true
+	case Decimal, ratDecimal:
+		return tagDecimal, true
+	case time.Time:
+		return tagTime, true
+	case ZonedTime:
+		return tagZonedTime, true
+	case uuid.UUID:
+		return tagUUID, true
+	case string:
+		return tagString, true
+	case []byte, LexKey:
+		return tagBytes, true
+	case LengthPrefixed:
+		return tagLengthPrefixed, true
+	case TriBool:
+		return tagTriBool, true
+	}
+	return 0, false
+}
@@ -0,0 +1,111 @@
+package lexkey
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldShowUntaggedCollisionsBetweenEmptyStringNilAndFalse(t *testing.T) {
+	// Act / Assert: the default format cannot tell these apart
+	assert.Equal(t, Encode(nil), Encode(false))
+	assert.Equal(t, Encode("", "x")[1:], Encode("x"))
+	assert.Equal(t, Encode(nil, "x")[1:], Encode("", "x"))
+}
+
+func TestShouldEncodeTaggedParts(t *testing.T) {
+	enc := &Encoder{Separator: Separator, EndMarker: EndMarker, TypeTags: true}
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{"nil", nil, "10"},
+		{"false", false, "1100"},
+		{"true", true, "1101"},
+		{"zero int", 0, "128000000000000000"},
+		{"zero uint", uint8(0), "130000000000000000"},
+		{"empty string", "", "1a"},
+		{"string", "a", "1a61"},
+		{"bytes", []byte{0x01}, "1b01"},
+		{"end marker", struct{}{}, "ff"},
+		{"json integer", json.Number("0"), "128000000000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			key, err := enc.NewLexKey(tt.value)
+
+			// Assert
+			require.NoError(t, err)
+			test.AssertHexEqual(t, tt.expected, key)
+		})
+	}
+}
+
+func TestShouldOrderTaggedEmptyStringFalseNilAndZeroDistinctly(t *testing.T) {
+	// Arrange
+	enc := &Encoder{Separator: Separator, EndMarker: EndMarker, TypeTags: true}
+	ordered := []LexKey{
+		enc.Encode(nil, "x"),
+		enc.Encode(false, "x"),
+		enc.Encode(true, "x"),
+		enc.Encode(0, "x"),
+		enc.Encode(uint64(0), "x"),
+		enc.Encode(0.0, "x"),
+		enc.Encode(time.Unix(0, 0), "x"),
+		enc.Encode("", "x"),
+		enc.Encode("a", "x"),
+		enc.Encode([]byte{}, "x"),
+		enc.Encode(struct{}{}, "x"),
+	}
+
+	// Act / Assert
+	for i := 1; i < len(ordered); i++ {
+		assert.Negative(t, Compare(ordered[i-1], ordered[i]), "key %d should sort before key %d", i-1, i)
+	}
+}
+
+func TestShouldKeepTaggedRangeBoundsAroundExtensions(t *testing.T) {
+	// Arrange
+	enc := &Encoder{Separator: Separator, EndMarker: EndMarker, TypeTags: true}
+	first := enc.EncodeFirst("tenant")
+	last := enc.EncodeLast("tenant")
+
+	// Act / Assert
+	for _, k := range []LexKey{enc.Encode("tenant", nil), enc.Encode("tenant", ""), enc.Encode("tenant", struct{}{})} {
+		assert.Negative(t, Compare(first, k))
+		assert.Negative(t, Compare(k, last))
+	}
+}
+
+func TestShouldSizeTaggedKeysExactly(t *testing.T) {
+	// Arrange
+	enc := &Encoder{Separator: Separator, EndMarker: EndMarker, TypeTags: true}
+	parts := []any{"a", nil, 1, true, struct{}{}}
+	expected := enc.Encode(parts...)
+	dst := make([]byte, len(expected))
+
+	// Act
+	n, err := enc.EncodeInto(dst, parts...)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, LexKey(dst[:n]))
+}
+
+func TestShouldRejectTypeTagsOverlappingMarkerBytes(t *testing.T) {
+	// Arrange
+	enc := &Encoder{Separator: 0x10, EndMarker: EndMarker, TypeTags: true}
+
+	// Act
+	_, err := enc.NewLexKey("a")
+
+	// Assert
+	require.Error(t, err)
+}
@@ -0,0 +1,55 @@
+package lexkey
+
+import (
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// defaultPorts maps schemes to the port that normalizeURL drops as redundant.
+var defaultPorts = map[string]string{"http": "80", "https": "443", "ws": "80", "wss": "443"}
+
+// EncodeURL encodes u as a string part after normalizing it, so equivalent URLs produce equal
+// keys: the scheme and host are lowercased, a default port (80 for http/ws, 443 for https/wss)
+// is dropped, an empty path on a URL with a host becomes "/", and the "&"-separated query
+// pairs are sorted by their raw name (values of a repeated name keep their order). Query pairs
+// are not decoded or re-escaped, so malformed pairs are kept and "a=%41" differs from "a=A".
+// Path and fragment case is preserved. A nil URL encodes like nil. u is not modified.
+func EncodeURL(u *url.URL) LexKey {
+	if u == nil {
+		return Encode(nil)
+	}
+	return Encode(normalizeURL(u))
+}
+
+// normalizeURL returns the canonical string form of u used by EncodeURL.
+func normalizeURL(u *url.URL) string {
+	n := *u
+	n.Scheme = strings.ToLower(n.Scheme)
+	host, port := strings.ToLower(n.Hostname()), n.Port()
+	if port == defaultPorts[n.Scheme] {
+		port = ""
+	}
+	switch {
+	case port != "":
+		n.Host = net.JoinHostPort(host, port)
+	case strings.Contains(host, ":"):
+		n.Host = "[" + host + "]" // IPv6 literal
+	default:
+		n.Host = host
+	}
+	if n.Host != "" && n.Path == "" {
+		n.Path = "/"
+	}
+	if n.RawQuery != "" {
+		pairs := strings.Split(n.RawQuery, "&")
+		slices.SortStableFunc(pairs, func(a, b string) int {
+			nameA, _, _ := strings.Cut(a, "=")
+			nameB, _, _ := strings.Cut(b, "=")
+			return strings.Compare(nameA, nameB)
+		})
+		n.RawQuery = strings.Join(pairs, "&")
+	}
+	return n.String()
+}
@@ -0,0 +1,82 @@
+package lexkey
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldEncodeEquivalentURLsEqually(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"query parameter order", "https://example.com/p?b=2&a=1", "https://example.com/p?a=1&b=2"},
+		{"host case", "https://EXAMPLE.com/p", "https://example.com/p"},
+		{"scheme case", "HTTPS://example.com/p", "https://example.com/p"},
+		{"default port", "http://example.com:80/p", "http://example.com/p"},
+		{"empty path", "https://example.com", "https://example.com/"},
+		{"ipv6 host case", "http://[FE80::1]:8080/", "http://[fe80::1]:8080/"},
+		{"malformed pair order", "https://example.com/p?b=1&a=%zz", "https://example.com/p?a=%zz&b=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			a, err := url.Parse(tt.a)
+			require.NoError(t, err)
+			b, err := url.Parse(tt.b)
+			require.NoError(t, err)
+
+			// Act / Assert
+			assert.Equal(t, EncodeURL(b), EncodeURL(a))
+		})
+	}
+}
+
+func TestShouldEncodeDifferentURLsDistinctly(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"path case", "https://example.com/A", "https://example.com/a"},
+		{"non-default port", "https://example.com:8443/", "https://example.com/"},
+		{"repeated parameter order", "https://example.com/?a=1&a=2", "https://example.com/?a=2&a=1"},
+		{"bad escape in query", "https://example.com/?a=%zz&b=1", "https://example.com/?b=1"},
+		{"semicolon in query", "https://example.com/?a;b=1", "https://example.com/"},
+		{"query escaping", "https://example.com/?a=%41", "https://example.com/?a=A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			a, err := url.Parse(tt.a)
+			require.NoError(t, err)
+			b, err := url.Parse(tt.b)
+			require.NoError(t, err)
+
+			// Act / Assert
+			assert.NotEqual(t, EncodeURL(b), EncodeURL(a))
+		})
+	}
+}
+
+func TestShouldEncodeNormalizedURLString(t *testing.T) {
+	// Arrange
+	u, err := url.Parse("HTTPS://Example.COM:443/Path?z=1&a=2#frag")
+	require.NoError(t, err)
+
+	// Act
+	key := EncodeURL(u)
+
+	// Assert
+	assert.Equal(t, Encode("https://example.com/Path?a=2&z=1#frag"), key)
+	assert.Equal(t, "Example.COM:443", u.Host)
+}
+
+func TestShouldEncodeNilURLAsNil(t *testing.T) {
+	// Act / Assert
+	assert.Equal(t, Encode(nil), EncodeURL(nil))
+}
@@ -0,0 +1,15 @@
+package lexkey
+
+import "github.com/google/uuid"
+
+// uuidTimePrefixSize is the width of the UUIDv7 Unix millisecond timestamp.
+const uuidTimePrefixSize = 6
+
+// EncodeUUIDTimePrefix returns the 48-bit big-endian Unix millisecond timestamp at the start
+// of a version 7 UUID, without its random tail, for coarse time bucketing. UUIDv7s already
+// sort by time, so this prefix sorts the same way and is a byte prefix of Encode(u).
+// For other UUID versions the first 6 bytes carry no timestamp and the result is not
+// meaningful.
+func EncodeUUIDTimePrefix(u uuid.UUID) LexKey {
+	return append(LexKey{}, u[:uuidTimePrefixSize]...)
+}
@@ -0,0 +1,72 @@
+package lexkey
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// uuidV7At builds a version 7 UUID for the given Unix millisecond with a fixed random tail.
+func uuidV7At(ms uint64, tail byte) uuid.UUID {
+	var u uuid.UUID
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], ms)
+	copy(u[:6], ts[2:])
+	for i := 6; i < 16; i++ {
+		u[i] = tail
+	}
+	u[6] = 0x70 | u[6]&0x0F // version 7
+	u[8] = 0x80 | u[8]&0x3F // RFC 4122 variant
+	return u
+}
+
+func TestShouldEncodeUUIDv7TimestampPrefix(t *testing.T) {
+	// Arrange
+	u := uuidV7At(1700000000000, 0xAB)
+
+	// Act
+	prefix := EncodeUUIDTimePrefix(u)
+
+	// Assert
+	test.AssertHexEqual(t, "018bcfe56800", prefix)
+	assert.Equal(t, Encode(u)[:6], prefix)
+}
+
+func TestShouldShareTimePrefixForUUIDsInSameMillisecond(t *testing.T) {
+	// Arrange
+	a := uuidV7At(1700000000000, 0x11)
+	b := uuidV7At(1700000000000, 0xEE)
+
+	// Act / Assert
+	assert.NotEqual(t, a, b)
+	assert.Equal(t, EncodeUUIDTimePrefix(a), EncodeUUIDTimePrefix(b))
+}
+
+func TestShouldSortEarlierUUIDTimePrefixesFirst(t *testing.T) {
+	// Arrange
+	earlier := uuidV7At(1700000000000, 0xFF)
+	later := uuidV7At(1700000000001, 0x00)
+
+	// Act / Assert
+	assert.Negative(t, Compare(EncodeUUIDTimePrefix(earlier), EncodeUUIDTimePrefix(later)))
+}
+
+func TestShouldMatchGeneratedUUIDv7Time(t *testing.T) {
+	// Arrange
+	u, err := uuid.NewV7()
+	require.NoError(t, err)
+	sec, nsec := u.Time().UnixTime()
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(time.Unix(sec, nsec).UnixMilli()))
+
+	// Act
+	prefix := EncodeUUIDTimePrefix(u)
+
+	// Assert
+	assert.Equal(t, LexKey(ts[2:]), prefix)
+}
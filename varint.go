@@ -0,0 +1,148 @@
+package lexkey
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// uvarintInlineMax is the largest value stored directly in the first byte of a sortable
+// uvarint. Larger values use a first byte of uvarintInlineMax+n followed by n bytes.
+const uvarintInlineMax = 0xF7
+
+// EncodeUvarintSortable encodes v in 1 to 9 bytes such that byte-wise comparison of the
+// results matches numeric order, unlike binary.PutUvarint, whose little-endian groups do not sort.
+//
+// Values up to 247 are a single byte holding the value. Larger values are a length byte
+// 0xF7+n (n = 1..8) followed by the value in n big-endian bytes, so longer encodings are
+// always larger values. The encoding is self-delimiting; see DecodeUvarintSortable.
+func EncodeUvarintSortable(v uint64) []byte {
+	if v <= uvarintInlineMax {
+		return []byte{byte(v)}
+	}
+	out := appendMinimalBigEndian([]byte{0}, v)
+	out[0] = byte(uvarintInlineMax + len(out) - 1)
+	return out
+}
+
+// DecodeUvarintSortable decodes a value written by EncodeUvarintSortable from the start of b,
+// returning the value and the number of bytes consumed. Non-minimal encodings are rejected,
+// so every value has exactly one valid encoding.
+func DecodeUvarintSortable(b []byte) (v uint64, n int, err error) {
+	if len(b) == 0 {
+		return 0, 0, errors.New("cannot decode sortable uvarint: empty input")
+	}
+	if b[0] <= uvarintInlineMax {
+		return uint64(b[0]), 1, nil
+	}
+	size := int(b[0] - uvarintInlineMax)
+	if len(b) < 1+size {
+		return 0, 0, fmt.Errorf("cannot decode sortable uvarint: need %d bytes, have %d", 1+size, len(b))
+	}
+	var buf [8]byte
+	copy(buf[8-size:], b[1:1+size])
+	v = binary.BigEndian.Uint64(buf[:])
+	if v <= uvarintInlineMax || (size > 1 && b[1] == 0) {
+		return 0, 0, fmt.Errorf("cannot decode sortable uvarint: non-minimal encoding of %d", v)
+	}
+	return v, 1 + size, nil
+}
+
+// Sortable varint first-byte ranges: 0x00-0x07 are negative values with a complemented
+// 8..1-byte payload, 0x08-0xF7 are inline values -120..119, and 0xF8-0xFF are positive values
+// with a 1..8-byte payload.
+const (
+	varintNegativeLongMax = 0x07
+	varintInlineMin       = -120
+	varintInlineMax       = 119
+	varintInlineBias      = 1<<63 - 128 // sign-flipped value that maps to first byte 0x00
+	varintPositiveLongMin = 0xF8
+	signBit64             = 1 << 63
+)
+
+// EncodeVarintSortable encodes v in 1 to 9 bytes such that byte-wise comparison of the
+// results matches numeric order: negatives sort before positives, and values close to zero
+// are shortest. Zig-zag varints are compact but do not sort, hence this custom scheme.
+//
+// Values in -120..119 are a single byte, 0x80+v. Values ≥ 120 are 0xF7+n followed by v in
+// n big-endian bytes. Values < -120 store m = -v-1 as 0x08-n followed by the complement of m
+// in n big-endian bytes, so larger magnitudes sort first. See DecodeVarintSortable.
+func EncodeVarintSortable(v int64) []byte {
+	u := lexUint64(v)
+	switch {
+	case v >= varintInlineMin && v <= varintInlineMax:
+		return []byte{byte(u - varintInlineBias)}
+	case v > varintInlineMax:
+		out := appendMinimalBigEndian([]byte{0}, u-signBit64)
+		out[0] = byte(varintPositiveLongMin - 2 + len(out))
+		return out
+	default:
+		out := appendMinimalBigEndian([]byte{0}, (signBit64-1)-u)
+		out[0] = byte(varintNegativeLongMax + 2 - len(out))
+		for i := 1; i < len(out); i++ {
+			out[i] = ^out[i]
+		}
+		return out
+	}
+}
+
+// DecodeVarintSortable decodes a value written by EncodeVarintSortable from the start of b,
+// returning the value and the number of bytes consumed. Non-minimal encodings are rejected.
+func DecodeVarintSortable(b []byte) (v int64, n int, err error) {
+	if len(b) == 0 {
+		return 0, 0, errors.New("cannot decode sortable varint: empty input")
+	}
+	first := b[0]
+	if first > varintNegativeLongMax && first < varintPositiveLongMin {
+		v, err = lexInt64(uint64(first) + varintInlineBias)
+		return v, 1, err
+	}
+	negative := first <= varintNegativeLongMax
+	size := int(first) - varintPositiveLongMin + 1
+	if negative {
+		size = varintNegativeLongMax + 1 - int(first)
+	}
+	if len(b) < 1+size {
+		return 0, 0, fmt.Errorf("cannot decode sortable varint: need %d bytes, have %d", 1+size, len(b))
+	}
+	var buf [8]byte
+	copy(buf[8-size:], b[1:1+size])
+	if negative {
+		for i := 8 - size; i < 8; i++ {
+			buf[i] = ^buf[i]
+		}
+	}
+	m := binary.BigEndian.Uint64(buf[:])
+	if m <= varintInlineMax || m >= signBit64 || (size > 1 && buf[8-size] == 0) {
+		return 0, 0, errors.New("cannot decode sortable varint: non-minimal encoding")
+	}
+	u := m + signBit64
+	if negative {
+		u = (signBit64 - 1) - m
+	}
+	v, err = lexInt64(u)
+	return v, 1 + size, err
+}
+
+// appendMinimalBigEndian appends v to dst in as few big-endian bytes as possible (at least one).
+func appendMinimalBigEndian(dst []byte, v uint64) []byte {
+	n := max((bits.Len64(v)+7)/8, 1)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	return append(dst, buf[8-n:]...)
+}
+
+// lexUint64 returns the sign-flipped unsigned form of v used by the int64 encoding.
+func lexUint64(v int64) uint64 {
+	var buf [8]byte
+	_ = putLexInt64(buf[:], v) // cannot fail: buf is exactly 8 bytes
+	return binary.BigEndian.Uint64(buf[:])
+}
+
+// lexInt64 reverses lexUint64.
+func lexInt64(u uint64) (int64, error) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], u)
+	return getLexInt64(buf[:])
+}
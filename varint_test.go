@@ -0,0 +1,168 @@
+package lexkey
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldEncodeUvarintSortableCompactly(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    uint64
+		expected string
+	}{
+		{"zero", 0, "00"},
+		{"largest inline", 247, "f7"},
+		{"smallest one-byte payload", 248, "f8f8"},
+		{"two-byte payload", 16384, "f94000"},
+		{"max uint64", math.MaxUint64, "ffffffffffffffffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			encoded := EncodeUvarintSortable(tt.value)
+
+			// Assert
+			test.AssertHexEqual(t, tt.expected, encoded)
+		})
+	}
+}
+
+func TestShouldPreserveOrderForUvarintSortable(t *testing.T) {
+	// Arrange
+	values := []uint64{0, 1, 127, 128, 247, 248, 255, 256, 16383, 16384, 1 << 32, math.MaxUint64 - 1, math.MaxUint64}
+
+	// Act / Assert
+	for i := 1; i < len(values); i++ {
+		prev, cur := EncodeUvarintSortable(values[i-1]), EncodeUvarintSortable(values[i])
+		assert.Negative(t, bytes.Compare(prev, cur), "%d should sort before %d", values[i-1], values[i])
+	}
+}
+
+func TestShouldRoundTripUvarintSortable(t *testing.T) {
+	for _, v := range []uint64{0, 127, 128, 247, 248, 16383, 16384, 1<<56 - 1, 1 << 56, math.MaxUint64} {
+		// Arrange
+		encoded := append(EncodeUvarintSortable(v), 0xAA) // trailing byte is not consumed
+
+		// Act
+		decoded, n, err := DecodeUvarintSortable(encoded)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, v, decoded)
+		assert.Equal(t, len(encoded)-1, n)
+	}
+}
+
+func TestShouldRejectInvalidUvarintSortable(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{"empty", nil},
+		{"truncated payload", []byte{0xf9, 0x40}},
+		{"inline value with length byte", []byte{0xf8, 0x05}},
+		{"leading zero payload byte", []byte{0xf9, 0x00, 0xff}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, _, err := DecodeUvarintSortable(tt.input)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestShouldEncodeVarintSortableCompactly(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    int64
+		expected string
+	}{
+		{"min int64", math.MinInt64, "008000000000000000"},
+		{"largest two-byte negative", -121, "0787"},
+		{"smallest inline", -120, "08"},
+		{"minus one", -1, "7f"},
+		{"zero", 0, "80"},
+		{"one", 1, "81"},
+		{"largest inline", 119, "f7"},
+		{"smallest one-byte payload", 120, "f878"},
+		{"max int64", math.MaxInt64, "ff7fffffffffffffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			encoded := EncodeVarintSortable(tt.value)
+
+			// Assert
+			test.AssertHexEqual(t, tt.expected, encoded)
+		})
+	}
+}
+
+func TestShouldPreserveOrderForVarintSortable(t *testing.T) {
+	// Arrange
+	values := []int64{
+		math.MinInt64, math.MinInt64 + 1, -1 << 32, -65536, -257, -256, -121, -120, -1,
+		0, 1, 119, 120, 255, 256, 65535, 1 << 32, math.MaxInt64 - 1, math.MaxInt64,
+	}
+
+	// Act / Assert
+	for i := 1; i < len(values); i++ {
+		prev, cur := EncodeVarintSortable(values[i-1]), EncodeVarintSortable(values[i])
+		assert.Negative(t, bytes.Compare(prev, cur), "%d should sort before %d", values[i-1], values[i])
+	}
+}
+
+func TestShouldRoundTripVarintSortable(t *testing.T) {
+	for v := int64(-70000); v <= 70000; v += 7 {
+		assertVarintRoundTrip(t, v)
+	}
+	for _, v := range []int64{math.MinInt64, math.MinInt64 + 1, -121, -120, 119, 120, math.MaxInt64} {
+		assertVarintRoundTrip(t, v)
+	}
+}
+
+func assertVarintRoundTrip(t *testing.T, v int64) {
+	t.Helper()
+	encoded := append(EncodeVarintSortable(v), 0xAA) // trailing byte is not consumed
+	decoded, n, err := DecodeVarintSortable(encoded)
+	require.NoError(t, err)
+	require.Equal(t, v, decoded)
+	require.Equal(t, len(encoded)-1, n)
+}
+
+func TestShouldRejectInvalidVarintSortable(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{"empty", nil},
+		{"truncated positive payload", []byte{0xf9, 0x40}},
+		{"truncated negative payload", []byte{0x00, 0x80}},
+		{"inline positive with length byte", []byte{0xf8, 0x05}},
+		{"inline negative with length byte", []byte{0x07, 0xff}},
+		{"leading zero payload byte", []byte{0xf9, 0x00, 0xff}},
+		{"positive payload beyond int64", []byte{0xff, 0x80, 0, 0, 0, 0, 0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, _, err := DecodeVarintSortable(tt.input)
+
+			// Assert
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package lexkey
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// EnvelopeVersion is the format version written by WrapVersioned.
+const EnvelopeVersion byte = 1
+
+// envelopeOverhead is the version byte plus the trailing CRC-32.
+const envelopeOverhead = 1 + crc32.Size
+
+// crcTable is the Castagnoli table, which has hardware support on common platforms.
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// WrapVersioned returns key in an at-rest envelope: the EnvelopeVersion byte, the key
+// bytes, then a big-endian CRC-32C of both, so the encoding can evolve and corruption is
+// detected by UnwrapVersioned.
+//
+// The envelope is for storage, not indexing: the version byte and checksum do not preserve
+// ordering, so never compare, sort or range-scan wrapped bytes. Unwrap before comparing.
+func WrapVersioned(key LexKey) []byte {
+	out := make([]byte, len(key)+envelopeOverhead)
+	out[0] = EnvelopeVersion
+	n := 1 + copy(out[1:], key)
+	binary.BigEndian.PutUint32(out[n:], crc32.Checksum(out[:n], crcTable))
+	return out
+}
+
+// UnwrapVersioned validates an envelope produced by WrapVersioned and returns the key.
+// The version byte is checked first, since other versions may lay out or checksum the rest
+// differently: returns an error wrapping ErrUnsupportedVersion if it is not EnvelopeVersion,
+// or ErrCorruptEnvelope if the data is empty, truncated or the checksum does not match.
+// The result never aliases data.
+func UnwrapVersioned(data []byte) (LexKey, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("cannot unwrap LexKey: %w: no version byte", ErrCorruptEnvelope)
+	}
+	if data[0] != EnvelopeVersion {
+		return nil, fmt.Errorf("cannot unwrap LexKey: %w %d", ErrUnsupportedVersion, data[0])
+	}
+	if len(data) < envelopeOverhead {
+		return nil, fmt.Errorf("cannot unwrap LexKey: %w: need at least %d bytes, have %d", ErrCorruptEnvelope, envelopeOverhead, len(data))
+	}
+	n := len(data) - crc32.Size
+	if want, got := binary.BigEndian.Uint32(data[n:]), crc32.Checksum(data[:n], crcTable); want != got {
+		return nil, fmt.Errorf("cannot unwrap LexKey: %w: checksum 0x%08x, want 0x%08x", ErrCorruptEnvelope, got, want)
+	}
+	return append(LexKey{}, data[1:n]...), nil
+}
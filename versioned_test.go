@@ -0,0 +1,109 @@
+package lexkey
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldRoundTripVersionedEnvelope(t *testing.T) {
+	tests := []struct {
+		name string
+		key  LexKey
+	}{
+		{"empty key", LexKey{}},
+		{"multi-part key", Encode("tenant", 42, true)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			wrapped := WrapVersioned(tt.key)
+			key, err := UnwrapVersioned(wrapped)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.key, key)
+			assert.Len(t, wrapped, len(tt.key)+5)
+			assert.Equal(t, EnvelopeVersion, wrapped[0])
+		})
+	}
+}
+
+func TestShouldDetectCorruptedEnvelope(t *testing.T) {
+	wrapped := WrapVersioned(Encode("tenant", 42))
+	tests := []struct {
+		name    string
+		corrupt func([]byte) []byte
+	}{
+		{"flipped key bit", func(b []byte) []byte { b[3] ^= 0x01; return b }},
+		{"flipped checksum bit", func(b []byte) []byte { b[len(b)-1] ^= 0x80; return b }},
+		{"truncated", func(b []byte) []byte { return b[:len(b)-1] }},
+		{"too short", func(b []byte) []byte { return b[:4] }},
+		{"empty", func(b []byte) []byte { return b[:0] }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			data := tt.corrupt(append([]byte{}, wrapped...))
+
+			// Act
+			_, err := UnwrapVersioned(data)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrCorruptEnvelope)
+		})
+	}
+}
+
+func TestShouldRejectUnknownEnvelopeVersion(t *testing.T) {
+	// Arrange: a well-formed envelope with a future version and a valid checksum
+	data := []byte{EnvelopeVersion + 1, 'a', 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(data[2:], crc32.Checksum(data[:2], crc32.MakeTable(crc32.Castagnoli)))
+
+	// Act
+	_, err := UnwrapVersioned(data)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+	assert.NotErrorIs(t, err, ErrCorruptEnvelope)
+}
+
+func TestShouldRejectFutureEnvelopeLayoutAsUnsupportedVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"leading 8-byte checksum", []byte{EnvelopeVersion + 1, 1, 2, 3, 4, 5, 6, 7, 8, 'a'}},
+		{"no checksum", []byte{EnvelopeVersion + 1, 'a'}},
+		{"version byte only", []byte{EnvelopeVersion + 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := UnwrapVersioned(tt.data)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrUnsupportedVersion)
+			assert.NotErrorIs(t, err, ErrCorruptEnvelope)
+		})
+	}
+}
+
+func TestShouldNotAliasEnvelopeWhenUnwrapping(t *testing.T) {
+	// Arrange
+	wrapped := WrapVersioned(Encode("abc"))
+
+	// Act
+	key, err := UnwrapVersioned(wrapped)
+	require.NoError(t, err)
+	wrapped[1] = 'z'
+
+	// Assert
+	assert.Equal(t, Encode("abc"), key)
+}
@@ -0,0 +1,65 @@
+package lexkey
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// zonedTimeSize is the encoded width of a ZonedTime: 8 bytes of instant and 4 of offset.
+const zonedTimeSize = 12
+
+// ZonedTime marks a time.Time part to be encoded together with its zone offset, so the
+// original local wall-clock time can be decoded back. Plain time.Time parts are stored as
+// the UTC instant only.
+//
+// Keys sort by instant first, exactly like time.Time parts; equal instants in different
+// zones then sort by offset. Only the offset survives a round trip: Decode returns a time
+// in a fixed zone without the original zone name (UTC when the offset is zero).
+type ZonedTime time.Time
+
+// encodeZonedTime writes the sign-flipped UnixNano instant followed by the zone offset in
+// seconds as a sign-flipped big-endian int32.
+func encodeZonedTime(dst []byte, t time.Time) (int, error) {
+	_, offset := t.Zone()
+	if offset < math.MinInt32 || offset > math.MaxInt32 {
+		return 0, fmt.Errorf("zone offset %d out of range", offset)
+	}
+	if err := putLexInt64(dst, t.UnixNano()); err != nil {
+		return 0, err
+	}
+	if err := putLexInt32(dst[8:], int32(offset)); err != nil {
+		return 0, err
+	}
+	return zonedTimeSize, nil
+}
+
+// decodeZonedTime reverses encodeZonedTime.
+func decodeZonedTime(seg []byte) (ZonedTime, error) {
+	n, err := getLexInt64(seg)
+	if err != nil {
+		return ZonedTime{}, err
+	}
+	offset, err := getLexInt32(seg[8:])
+	if err != nil {
+		return ZonedTime{}, err
+	}
+	loc := time.UTC
+	if offset != 0 {
+		loc = time.FixedZone("", int(offset))
+	}
+	return ZonedTime(time.Unix(0, n).In(loc)), nil
+}
+
+// getLexInt32 reverses putLexInt32, reading 4 big-endian bytes and undoing the sign-bit flip.
+func getLexInt32(src []byte) (int32, error) {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], binary.BigEndian.Uint32(src)^0x80000000)
+	var v int32
+	if err := binary.Read(bytes.NewReader(buf[:]), binary.BigEndian, &v); err != nil {
+		return 0, err
+	}
+	return v, nil
+}
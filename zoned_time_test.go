@@ -0,0 +1,81 @@
+package lexkey
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fgrzl/lexkey/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldEncodeZonedTimeAsInstantThenOffset(t *testing.T) {
+	// Arrange
+	plus2 := time.FixedZone("CEST", 2*60*60)
+	at := time.Unix(1700000000, 0).In(plus2)
+
+	// Act
+	key := Encode(ZonedTime(at))
+
+	// Assert
+	test.AssertHexEqual(t, "97979cfe362a000080001c20", key)
+	assert.Equal(t, Encode(at), key[:8])
+}
+
+func TestShouldSortZonedTimesByInstantRegardlessOfZone(t *testing.T) {
+	// Arrange
+	tokyo := time.FixedZone("JST", 9*60*60)
+	newYork := time.FixedZone("EST", -5*60*60)
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	earlierInTokyo := base.Add(-time.Hour).In(tokyo) // 20:00 local, earlier instant
+	laterInNewYork := base.In(newYork)               // 07:00 local, later instant
+
+	// Act
+	cmp := Compare(Encode(ZonedTime(earlierInTokyo)), Encode(ZonedTime(laterInNewYork)))
+
+	// Assert
+	assert.Negative(t, cmp)
+}
+
+func TestShouldOrderEqualInstantsByOffset(t *testing.T) {
+	// Arrange
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	west := ZonedTime(at.In(time.FixedZone("", -3600)))
+	utc := ZonedTime(at)
+	east := ZonedTime(at.In(time.FixedZone("", 3600)))
+
+	// Act / Assert
+	assert.Negative(t, Compare(Encode(west), Encode(utc)))
+	assert.Negative(t, Compare(Encode(utc), Encode(east)))
+}
+
+func TestShouldDecodeZonedTimeWithOriginalOffset(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset int
+	}{
+		{"positive offset", 5*60*60 + 30*60},
+		{"negative offset", -8 * 60 * 60},
+		{"utc", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			at := time.Date(2024, 12, 31, 23, 59, 59, 123456789, time.FixedZone("local", tt.offset))
+			key := Encode("audit", ZonedTime(at))
+
+			// Act
+			values, err := Decode(key, stringType, reflect.TypeOf(ZonedTime{}))
+
+			// Assert
+			require.NoError(t, err)
+			decoded := time.Time(values[1].(ZonedTime))
+			_, offset := decoded.Zone()
+			assert.Equal(t, tt.offset, offset)
+			assert.True(t, at.Equal(decoded))
+			assert.Equal(t, at.Format(time.RFC3339Nano), decoded.Format(time.RFC3339Nano))
+		})
+	}
+}